@@ -41,7 +41,9 @@ func configCMD() *cli.Command {
 					}
 
 					buf := bytes.NewBuffer(nil)
-					enc := yaml.NewEncoder(buf, yaml.Indent(2))
+					enc := yaml.NewEncoder(buf, yaml.Indent(2), yaml.WithComment(yaml.CommentMap{
+						"$.scripts": {yaml.HeadComment(" scripts have no memory limit: run trusted scripts only")},
+					}))
 					if err := enc.Encode(conf); err != nil {
 						return fmt.Errorf("failed to encode yaml: %w", err)
 					}
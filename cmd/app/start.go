@@ -51,7 +51,10 @@ func startCMD(l logger.ExtendedLogger) *cli.Command {
 			}
 
 			// services
-			parserService := parser.New(l, conf)
+			parserService, err := parser.New(l, conf)
+			if err != nil {
+				return fmt.Errorf("failed to init parser service: %w", err)
+			}
 			telegramService := telegram.New(l, conf, parserService, lm)
 			// grpc servers
 			botGrpcServer := api.NewBotGrpcServer(parserService)
@@ -65,6 +68,7 @@ func startCMD(l logger.ExtendedLogger) *cli.Command {
 
 			ln.ServicesRunner().Register(
 				launcher.NewService(launcher.WithService(pingpong.New(l))),
+				launcher.NewService(launcher.WithService(parserService)),
 				launcher.NewService(launcher.WithService(grpcServer)),
 				launcher.NewService(launcher.WithService(telegramService)),
 			)
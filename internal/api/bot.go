@@ -5,8 +5,10 @@ import (
 	"fmt"
 
 	"github.com/sxwebdev/downloaderbot/internal/media"
+	"github.com/sxwebdev/downloaderbot/internal/models"
 	"github.com/sxwebdev/downloaderbot/internal/services/parser"
 	"github.com/sxwebdev/downloaderbot/pb"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
 	"google.golang.org/grpc"
 )
 
@@ -33,36 +35,63 @@ func (s *grpcServer) GetMedia(ctx context.Context, req *pb.GetMediaRequest) (*pb
 	// get link info
 	linkInfo, err := s.parserService.GetLinkInfo(ctx, req.GetUrl())
 	if err != nil {
-		return nil, fmt.Errorf("get link info error: %w", err)
+		return nil, toStatus(fmt.Errorf("get link info error: %w", err))
 	}
 
 	// get media data from link
 	data, err := s.parserService.GetMedia(ctx, linkInfo)
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 
 	// define response
 	resp := &pb.GetMediaResponse{
-		Title:   data.Title,
-		Caption: data.Caption,
-		Source:  string(data.Source),
-		Items:   make([]*pb.MediaItem, len(data.Items)),
+		Title:                   data.Title,
+		Caption:                 data.Caption,
+		Source:                  string(data.Source),
+		Items:                   make([]*pb.MediaItem, len(data.Items)),
+		Formats:                 linkInfo.Capabilities.Output == extractor.OutputFormats,
+		DownloadHeadersRequired: linkInfo.Capabilities.DownloadHeaders,
+		Author:                  data.Author,
+		AuthorVerified:          data.Owner != nil && data.Owner.IsVerified,
+		TakenAt:                 data.TakenAt,
+		Likes:                   data.Likes,
+		Comments:                data.Comments,
 	}
 
-	// set pb media items. The API returns URLs only; for items that need
+	// set pb media items. The API returns URLs only; for sources that need
 	// download headers (e.g. TikTok) the raw URL is still returned but is not
-	// directly fetchable by clients — see README "Known limitations".
+	// directly fetchable by clients, which DownloadHeadersRequired tells them —
+	// see README "Known limitations".
 	for index, item := range data.Items {
-		url, ok := media.Default().DirectURL(item)
-		if !ok {
-			url = item.Url
-		}
 		resp.Items[index] = &pb.MediaItem{
-			Url:  url,
+			Url:  directURL(item),
 			Type: string(item.Type),
 		}
+		for _, v := range item.Variants {
+			resp.Items[index].Variants = append(resp.Items[index].Variants, &pb.Variant{
+				Url:           directURL(item.WithVariant(v)),
+				Quality:       v.Quality,
+				MimeType:      v.MimeType,
+				Codec:         v.Codec,
+				Bitrate:       int64(v.Bitrate),
+				Width:         int32(v.Width),
+				Height:        int32(v.Height),
+				ContentLength: v.ContentLength,
+				HasVideo:      v.HasVideo,
+				HasAudio:      v.HasAudio,
+			})
+		}
 	}
 
 	return resp, nil
 }
+
+// directURL returns the URL clients can fetch the item from, the raw URL when
+// it needs download headers.
+func directURL(item *models.MediaItem) string {
+	if url, ok := media.Default().DirectURL(item); ok {
+		return url
+	}
+	return item.Url
+}
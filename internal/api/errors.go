@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sxwebdev/downloaderbot/internal/services/parser"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorCodes maps the extractor error taxonomy to gRPC codes.
+var errorCodes = map[error]codes.Code{
+	extractor.ErrUnsupportedURL:  codes.InvalidArgument,
+	extractor.ErrNotSupported:    codes.InvalidArgument,
+	extractor.ErrBlocked:         codes.Unavailable,
+	extractor.ErrPrivate:         codes.PermissionDenied,
+	extractor.ErrNotFound:        codes.NotFound,
+	extractor.ErrLoginRequired:   codes.FailedPrecondition,
+	extractor.ErrRateLimited:     codes.ResourceExhausted,
+	extractor.ErrGeoBlocked:      codes.FailedPrecondition,
+	extractor.ErrUpstreamChanged: codes.Unavailable,
+}
+
+// toStatus turns an error of the parser into a gRPC status error, so clients
+// can tell a private post from an outage without parsing messages.
+func toStatus(err error) error {
+	code := codes.Unknown
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, parser.ErrSourceUnavailable):
+		code = codes.Unavailable
+	default:
+		if c, ok := errorCodes[extractor.Kind(err)]; ok {
+			code = c
+		}
+	}
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,22 @@
+// Package cache keeps extraction results, so a link requested again shortly
+// after is served without running its extractor. Media URLs of most sources
+// are signed and expire, which bounds how long a result may be kept: see TTL.
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/sxwebdev/downloaderbot/internal/models"
+)
+
+// Store keeps extraction results by key. Implementations must be safe for
+// concurrent use and must not share the returned media between callers, as
+// callers may modify it.
+type Store interface {
+	// Get returns the media stored under key. ok is false when there is none
+	// or it has expired.
+	Get(ctx context.Context, key string) (media *models.Media, ok bool, err error)
+	// Set stores media under key for ttl.
+	Set(ctx context.Context, key string, media *models.Media, ttl time.Duration) error
+}
@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sxwebdev/downloaderbot/internal/models"
+)
+
+// LRU is an in-memory Store holding at most size results. When it is full the
+// least recently used result is evicted.
+type LRU struct {
+	mu    sync.Mutex
+	size  int
+	items map[string]*list.Element
+	order *list.List // front is the most recently used
+	now   func() time.Time
+}
+
+type lruEntry struct {
+	key     string
+	media   *models.Media
+	expires time.Time
+}
+
+var _ Store = (*LRU)(nil)
+
+// NewLRU creates an in-memory store for size results. A size below 1 is
+// treated as 1.
+func NewLRU(size int) *LRU {
+	return &LRU{
+		size:  max(size, 1),
+		items: make(map[string]*list.Element),
+		order: list.New(),
+		now:   time.Now,
+	}
+}
+
+// Get implements Store.
+func (c *LRU) Get(_ context.Context, key string) (*models.Media, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := el.Value.(*lruEntry)
+	if !c.now().Before(entry.expires) {
+		c.remove(el)
+		return nil, false, nil
+	}
+
+	c.order.MoveToFront(el)
+	return entry.media.Clone(), true, nil
+}
+
+// Set implements Store. A non-positive ttl removes key instead.
+func (c *LRU) Set(_ context.Context, key string, media *models.Media, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.remove(el)
+	}
+	if ttl <= 0 {
+		return nil
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry{
+		key:     key,
+		media:   media.Clone(),
+		expires: c.now().Add(ttl),
+	})
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+
+	return nil
+}
+
+// Len returns the number of stored results, expired ones included.
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *LRU) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.items, el.Value.(*lruEntry).key)
+}
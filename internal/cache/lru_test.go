@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sxwebdev/downloaderbot/internal/models"
+)
+
+func TestLRU(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1_700_000_000, 0)
+	c := NewLRU(2)
+	c.now = func() time.Time { return now }
+
+	media := func(title string) *models.Media {
+		return &models.Media{Title: title, Items: []*models.MediaItem{{Url: "https://cdn.test/" + title}}}
+	}
+	get := func(key string) *models.Media {
+		t.Helper()
+		m, ok, err := c.Get(ctx, key)
+		if err != nil {
+			t.Fatalf("Get(%q): %v", key, err)
+		}
+		if !ok {
+			return nil
+		}
+		return m
+	}
+
+	_ = c.Set(ctx, "a", media("a"), time.Minute)
+	_ = c.Set(ctx, "b", media("b"), time.Hour)
+	if m := get("a"); m == nil || m.Title != "a" {
+		t.Fatalf("want a, got %+v", m)
+	}
+
+	// a was used last, so b goes.
+	_ = c.Set(ctx, "c", media("c"), time.Hour)
+	if get("b") != nil {
+		t.Fatal("least recently used entry was not evicted")
+	}
+	if get("a") == nil || get("c") == nil || c.Len() != 2 {
+		t.Fatalf("unexpected entries, len %d", c.Len())
+	}
+
+	t.Run("results don't alias", func(t *testing.T) {
+		m := get("c")
+		m.Items[0].Url = "changed"
+		m.Items = nil
+		if got := get("c"); len(got.Items) != 1 || got.Items[0].Url != "https://cdn.test/c" {
+			t.Fatalf("stored media was modified: %+v", got)
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		if get("a") != nil {
+			t.Fatal("expired entry was served")
+		}
+		if c.Len() != 1 {
+			t.Fatalf("expired entry was not dropped, len %d", c.Len())
+		}
+	})
+
+	t.Run("zero ttl removes", func(t *testing.T) {
+		_ = c.Set(ctx, "c", media("c"), 0)
+		if get("c") != nil || c.Len() != 0 {
+			t.Fatal("entry stored with zero ttl")
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/sxwebdev/downloaderbot/internal/models"
+)
+
+// ExpiryMargin is how long before its URLs expire a result stops being served:
+// the caller still has to download the media.
+const ExpiryMargin = 5 * time.Minute
+
+// expiryParams are the query parameters CDNs put the URL expiry in, as Unix
+// seconds: Instagram and Facebook (oe, in hex), YouTube (expire) and TikTok
+// (x-expires).
+var expiryParams = []struct {
+	name string
+	base int
+}{
+	{"oe", 16},
+	{"expire", 10},
+	{"x-expires", 10},
+}
+
+// TTL returns how long media may be cached: maxTTL, cut short by the earliest
+// expiry among its URLs minus ExpiryMargin. It returns 0, meaning don't cache,
+// when a URL is about to expire.
+func TTL(media *models.Media, maxTTL time.Duration, now time.Time) time.Duration {
+	ttl := maxTTL
+	limit := func(rawURL string) {
+		expires, ok := Expiry(rawURL)
+		if !ok {
+			return
+		}
+		ttl = min(ttl, expires.Sub(now)-ExpiryMargin)
+	}
+
+	limit(media.Url)
+	for _, item := range media.Items {
+		limit(item.Url)
+		limit(item.ThumbnailUrl)
+		for _, v := range item.Variants {
+			limit(v.Url)
+		}
+	}
+
+	return max(ttl, 0)
+}
+
+// Expiry returns the time a signed media URL expires at, if it says so.
+func Expiry(rawURL string) (time.Time, bool) {
+	if rawURL == "" {
+		return time.Time{}, false
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	query := u.Query()
+	for _, param := range expiryParams {
+		value := query.Get(param.name)
+		if value == "" {
+			continue
+		}
+		sec, err := strconv.ParseInt(value, param.base, 64)
+		if err != nil || sec <= 0 {
+			continue
+		}
+		return time.Unix(sec, 0), true
+	}
+
+	return time.Time{}, false
+}
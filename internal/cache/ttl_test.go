@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sxwebdev/downloaderbot/internal/models"
+)
+
+func TestExpiry(t *testing.T) {
+	tests := []struct {
+		url  string
+		want int64
+	}{
+		{"https://scontent.cdninstagram.com/v/t51/1.mp4?_nc_ht=x&oe=6553F100&_nc_sid=1", 0x6553F100},
+		{"https://rr1---sn-abc.googlevideo.com/videoplayback?expire=1700000000&ei=x", 1700000000},
+		{"https://v16-webapp.tiktok.com/video/tos/1/?a=1988&x-expires=1700003600&x-signature=s", 1700003600},
+		{"https://cdn.example.test/clip.mp4", 0},
+		{"https://cdn.example.test/clip.mp4?oe=zz", 0},
+		{"", 0},
+	}
+
+	for _, tc := range tests {
+		got, ok := Expiry(tc.url)
+		if ok != (tc.want != 0) || (ok && got.Unix() != tc.want) {
+			t.Errorf("Expiry(%q) = %v, %v; want %d", tc.url, got.Unix(), ok, tc.want)
+		}
+	}
+}
+
+func TestTTL(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	media := func(urls ...string) *models.Media {
+		m := &models.Media{}
+		for _, u := range urls {
+			m.Items = append(m.Items, &models.MediaItem{Url: u})
+		}
+		return m
+	}
+
+	tests := []struct {
+		name  string
+		media *models.Media
+		want  time.Duration
+	}{
+		{"no expiry", media("https://cdn.example.test/a.mp4"), time.Hour},
+		{"earliest url wins", media(
+			"https://v16.tiktok.com/a?x-expires=1700001800",
+			"https://v16.tiktok.com/b?x-expires=1700001200",
+		), 20*time.Minute - ExpiryMargin},
+		{"far expiry keeps max", media("https://rr1.googlevideo.com/videoplayback?expire=1700086400"), time.Hour},
+		{"about to expire", media("https://rr1.googlevideo.com/videoplayback?expire=1700000060"), 0},
+		{"thumbnail counts", &models.Media{Items: []*models.MediaItem{{
+			Url:          "https://cdn.example.test/a.mp4",
+			ThumbnailUrl: "https://scontent.cdninstagram.com/t.jpg?oe=6553F6B0",
+		}}}, 0x6553F6B0*time.Second - time.Duration(now.Unix())*time.Second - ExpiryMargin},
+		{"variants count", &models.Media{Items: []*models.MediaItem{{
+			Url: "https://rr1.googlevideo.com/videoplayback?expire=1700086400",
+			Variants: []*models.Variant{
+				{Url: "https://rr1.googlevideo.com/videoplayback?expire=1700086400"},
+				{Url: "https://rr1.googlevideo.com/videoplayback?expire=1700001200"},
+			},
+		}}}, 20*time.Minute - ExpiryMargin},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := TTL(tc.media, time.Hour, now); got != tc.want {
+				t.Fatalf("TTL = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
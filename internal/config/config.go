@@ -1,6 +1,13 @@
 package config
 
 import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/sxwebdev/downloaderbot/pkg/extractor/declarative"
+
 	"github.com/tkcrm/mx/launcher/ops"
 	"github.com/tkcrm/mx/logger"
 	"github.com/tkcrm/mx/transport/grpc_transport"
@@ -12,4 +19,171 @@ type Config struct {
 	Ops                 ops.Config
 	Grpc                grpc_transport.Config
 	TelegramBotApiToken string `yaml:"telegram_bot_api_token" validate:"required" secret:"true" usage:"use token for your telegram bot"`
+	// TelegramBotApiUrl and TelegramMaxUploadSize point the bot at a local Bot
+	// API server, which takes uploads up to 2000MB instead of 50MB.
+	TelegramBotApiUrl     string    `yaml:"telegram_bot_api_url" usage:"url of a local telegram bot api server, empty for the public one"`
+	TelegramMaxUploadSize int64     `yaml:"telegram_max_upload_size" validate:"gte=0,excluded_without=TelegramBotApiUrl" usage:"maximum size in bytes of a file the bot uploads, 0 for the telegram bot api limit of 50MB; needs telegram_bot_api_url"`
+	TelegramSoundtrack    bool      `yaml:"telegram_soundtrack" usage:"allows to send the soundtrack of a video as an audio message after it"`
+	Cache                 Cache     `yaml:"cache"`
+	Lux                   Lux       `yaml:"lux"`
+	Instagram             Instagram `yaml:"instagram"`
+	Canary                Canary    `yaml:"canary"`
+	Breaker               Breaker   `yaml:"breaker"`
+	// Plugins holds the external-process extractors by name.
+	Plugins map[string]Plugin `yaml:"plugins" validate:"dive" usage:"external-process extractors by name"`
+	// Extractors holds the declarative extractors by name.
+	Extractors Extractors `yaml:"extractors" validate:"dive" usage:"declarative extractors by name"`
+	// Scripts holds the JavaScript extractors by name.
+	Scripts map[string]Script `yaml:"scripts" validate:"dive" usage:"javascript extractors by name"`
+}
+
+// Cache configures the extraction result cache.
+type Cache struct {
+	Enabled bool          `yaml:"enabled" default:"true" usage:"allows to cache extraction results"`
+	Size    int           `yaml:"size" default:"1000" validate:"gte=1" usage:"maximum number of cached extraction results"`
+	TTL     time.Duration `yaml:"ttl" default:"1h" usage:"how long a result is cached when its media URLs don't expire earlier"`
+}
+
+// Lux configures the lux-based extractors.
+type Lux struct {
+	// Sites holds per-site options by lux site name (bilibili, weibo, ...).
+	Sites map[string]LuxSite `yaml:"sites" usage:"per-site lux options by site name"`
+}
+
+// LuxSite configures the extraction of a lux site.
+type LuxSite struct {
+	CookieFile string        `yaml:"cookie_file" usage:"path to a cookie file for the site, in the Netscape format or as a Cookie header value; only bilibili, ixigua and youku use it"`
+	Playlist   bool          `yaml:"playlist" usage:"allows to extract every entry of multi-part videos, galleries and playlists"`
+	Items      string        `yaml:"items" usage:"playlist entries to extract, like 1,5,6,8-10"`
+	ItemStart  int           `yaml:"item_start" usage:"first playlist entry to extract"`
+	ItemEnd    int           `yaml:"item_end" usage:"last playlist entry to extract"`
+	Timeout    time.Duration `yaml:"timeout" usage:"how long an extraction of the site may take"`
+}
+
+// Instagram configures how Instagram posts are fetched.
+type Instagram struct {
+	Strategies     []string      `yaml:"strategies" default:"graphql,embed,browser" validate:"min=1,unique,dive,oneof=graphql embed browser" usage:"fetch strategies to try in order: graphql, embed and browser"`
+	GraphQLTimeout time.Duration `yaml:"graphql_timeout" default:"10s" validate:"gte=0" usage:"how long a fetch from the graphql endpoint may take, 0 for no limit"`
+	EmbedTimeout   time.Duration `yaml:"embed_timeout" default:"10s" validate:"gte=0" usage:"how long a fetch of the embed page may take, 0 for no limit"`
+	BrowserTimeout time.Duration `yaml:"browser_timeout" validate:"gte=0" usage:"how long loading the post in the browser may take, 0 for no limit"`
+	Cooldown       time.Duration `yaml:"cooldown" default:"5m" validate:"gt=0" usage:"how long a strategy that failed on a post another one served is skipped"`
+}
+
+// Canary configures the periodic checks of the extractors against links known
+// to work, which take a source that keeps failing them out of service.
+type Canary struct {
+	Enabled   bool                `yaml:"enabled" usage:"allows to check the extractors periodically against the canary links"`
+	Interval  time.Duration       `yaml:"interval" default:"10m" validate:"gt=0" usage:"how often the canary links are checked"`
+	Timeout   time.Duration       `yaml:"timeout" default:"1m" validate:"gt=0" usage:"how long the extraction of a canary link may take"`
+	Threshold int                 `yaml:"threshold" default:"3" validate:"gte=1" usage:"consecutive failed checks after which a source is degraded"`
+	URLs      map[string][]string `yaml:"urls" usage:"canary links by extractor name"`
+	// StatusAddr serves the state of every checked source, the degraded ones
+	// included, which the readiness probe only reports once all are degraded.
+	StatusAddr string `yaml:"status_addr" validate:"omitempty,hostname_port" usage:"listen address of the endpoint listing the degraded sources, empty to disable"`
+}
+
+// Breaker configures the circuit breakers that make requests to a failing
+// source fail fast.
+type Breaker struct {
+	Enabled     bool          `yaml:"enabled" default:"true" usage:"allows to stop extracting from a source after repeated failures"`
+	Failures    int           `yaml:"failures" default:"5" validate:"gte=1" usage:"failed extractions in a row that open the circuit breaker of a source"`
+	OpenTimeout time.Duration `yaml:"open_timeout" default:"1m" validate:"gt=0" usage:"how long a circuit breaker stays open before a trial request is let through"`
+}
+
+// Plugin configures an extractor that runs an external program, see
+// pkg/extractor/plugin for the output it must print.
+type Plugin struct {
+	Hosts           []string      `yaml:"hosts" validate:"required,min=1" usage:"hosts the plugin handles, wildcards like *.example.com included"`
+	Command         []string      `yaml:"command" validate:"required,min=1" usage:"program and arguments to run, the link is appended"`
+	Timeout         time.Duration `yaml:"timeout" usage:"how long a run may take before the process is killed"`
+	Priority        int           `yaml:"priority" usage:"position among the extractors of the same hosts, higher runs first"`
+	Inline          bool          `yaml:"inline" usage:"allows to offer the media in telegram inline mode"`
+	DownloadHeaders bool          `yaml:"download_headers" usage:"media urls need the headers the plugin returns"`
+	Formats         bool          `yaml:"formats" usage:"offer the item variants as download links instead of sending the items"`
+}
+
+// Script configures an extractor written in JavaScript, see
+// pkg/extractor/script for the API a script gets.
+type Script struct {
+	File            string        `yaml:"file" validate:"required" usage:"path to the script"`
+	Hosts           []string      `yaml:"hosts" usage:"hosts the script handles, wildcards like *.example.com included"`
+	Timeout         time.Duration `yaml:"timeout" usage:"how long a run may take before the script is stopped"`
+	MaxHostBytes    int64         `yaml:"max_host_bytes" validate:"gte=0" usage:"bytes of responses, pages and results the host api may hand to a run before the script is stopped, 0 for 64MB; scripts have no memory limit"`
+	Priority        int           `yaml:"priority" usage:"position among the extractors of the same hosts, higher runs first"`
+	Inline          bool          `yaml:"inline" usage:"allows to offer the media in telegram inline mode"`
+	DownloadHeaders bool          `yaml:"download_headers" usage:"media urls need the headers the script returns"`
+	Formats         bool          `yaml:"formats" usage:"offer the item variants as download links instead of sending the items"`
+}
+
+// Extractors holds the declarative extractors by name, see
+// pkg/extractor/declarative.
+type Extractors map[string]Extractor
+
+// Validate compiles the definitions, so that a broken regexp or jq expression
+// fails the config load instead of the first request.
+func (e Extractors) Validate() error {
+	for _, name := range slices.Sorted(maps.Keys(e)) {
+		if _, err := declarative.New(e[name].Definition(name)); err != nil {
+			return fmt.Errorf("extractors: %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Extractor configures a declarative extractor: where its JSON document comes
+// from and the jq expressions mapping it to media.
+type Extractor struct {
+	Hosts     []string          `yaml:"hosts" validate:"required,min=1" usage:"hosts the extractor handles, wildcards like *.example.com included"`
+	Path      string            `yaml:"path" usage:"regexp the link path must match, its groups fill the url placeholders"`
+	URL       string            `yaml:"url" usage:"url of the document with {name}, {1} and {url} placeholders, empty for the link itself"`
+	Headers   map[string]string `yaml:"headers" usage:"request headers"`
+	Embedded  string            `yaml:"embedded" usage:"regexp whose first group is the json document embedded in the page"`
+	Timeout   time.Duration     `yaml:"timeout" usage:"how long an extraction may take"`
+	Title     string            `yaml:"title" usage:"jq expression of the title"`
+	Caption   string            `yaml:"caption" usage:"jq expression of the caption"`
+	Thumbnail string            `yaml:"thumbnail" usage:"jq expression of the thumbnail url"`
+	Items     string            `yaml:"items" validate:"required" usage:"jq expression yielding one value per media item"`
+	Item      ExtractorItem     `yaml:"item"`
+}
+
+// ExtractorItem holds the jq expressions of the media item fields, evaluated
+// on each value the items expression yields.
+type ExtractorItem struct {
+	Type          string `yaml:"type" usage:"jq expression of the item type: video, photo or audio; video when empty"`
+	Url           string `yaml:"url" validate:"required" usage:"jq expression of the media url"`
+	Quality       string `yaml:"quality" usage:"jq expression of the quality label"`
+	MimeType      string `yaml:"mime_type" usage:"jq expression of the mime type"`
+	Width         string `yaml:"width" usage:"jq expression of the width"`
+	Height        string `yaml:"height" usage:"jq expression of the height"`
+	Duration      string `yaml:"duration" usage:"jq expression of the duration in seconds"`
+	ContentLength string `yaml:"content_length" usage:"jq expression of the size in bytes"`
+	Thumbnail     string `yaml:"thumbnail" usage:"jq expression of the thumbnail url"`
+}
+
+// Definition converts the config of the extractor called name.
+func (e Extractor) Definition(name string) declarative.Definition {
+	return declarative.Definition{
+		Name:      name,
+		Hosts:     e.Hosts,
+		Path:      e.Path,
+		URL:       e.URL,
+		Headers:   e.Headers,
+		Embedded:  e.Embedded,
+		Timeout:   e.Timeout,
+		Title:     e.Title,
+		Caption:   e.Caption,
+		Thumbnail: e.Thumbnail,
+		Items:     e.Items,
+		Item: declarative.ItemDefinition{
+			Type:          e.Item.Type,
+			Url:           e.Item.Url,
+			Quality:       e.Item.Quality,
+			MimeType:      e.Item.MimeType,
+			Width:         e.Item.Width,
+			Height:        e.Item.Height,
+			Duration:      e.Item.Duration,
+			ContentLength: e.Item.ContentLength,
+			Thumbnail:     e.Item.Thumbnail,
+		},
+	}
 }
@@ -6,6 +6,7 @@ package media
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -38,15 +39,60 @@ type Loader interface {
 	// inline queries that must offer a download link for files over Telegram's
 	// upload limit).
 	ContentLength(ctx context.Context, item *models.MediaItem) (int64, error)
+
+	// Probe reports the item's content type and size via a HEAD request,
+	// applying any required download headers, without downloading the body.
+	// Servers that reject HEAD are asked with a GET whose body is not read.
+	Probe(ctx context.Context, item *models.MediaItem) (*Info, error)
+}
+
+// StatusError is returned when the source answers with an unsuccessful
+// status.
+type StatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return "source returned " + e.Status
+}
+
+// Info is what the source reports about an item before it is downloaded.
+type Info struct {
+	// ContentType is the Content-Type header, empty when not reported.
+	ContentType string
+	// ContentLength is the size in bytes, -1 when not reported.
+	ContentLength int64
 }
 
 type httpLoader struct {
 	client *http.Client
+	// public downloads the items marked PublicOnly.
+	public *http.Client
+}
+
+// LoaderOption configures the HTTP loader.
+type LoaderOption func(*httpLoader)
+
+// WithHTTPClient sets the client used for every download, the items marked
+// PublicOnly included, which then lose the check of their address.
+func WithHTTPClient(c *http.Client) LoaderOption {
+	return func(l *httpLoader) {
+		l.client = c
+		l.public = c
+	}
 }
 
 // NewHTTPLoader creates the default HTTP-based loader.
-func NewHTTPLoader() Loader {
-	return &httpLoader{client: util.DefaultHttpClient()}
+func NewHTTPLoader(opts ...LoaderOption) Loader {
+	l := &httpLoader{
+		client: util.DefaultHttpClient(),
+		public: util.PublicHttpClient(),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
 }
 
 var defaultLoader = NewHTTPLoader()
@@ -79,39 +125,82 @@ func (l *httpLoader) Open(ctx context.Context, item *models.MediaItem) (*Content
 		req.Header.Set(k, v)
 	}
 
-	resp, err := l.client.Do(req)
+	resp, err := l.clientFor(item).Do(req)
 	if err != nil {
 		return nil, err
 	}
 	if resp.StatusCode/100 != 2 {
 		resp.Body.Close()
-		return nil, fmt.Errorf("source returned %s", resp.Status)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Status: resp.Status}
 	}
 
 	return &Content{Body: resp.Body, ContentLength: resp.ContentLength}, nil
 }
 
 func (l *httpLoader) ContentLength(ctx context.Context, item *models.MediaItem) (int64, error) {
+	resp, err := l.do(ctx, http.MethodHead, item)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	return resp.ContentLength, nil
+}
+
+func (l *httpLoader) Probe(ctx context.Context, item *models.MediaItem) (*Info, error) {
+	resp, err := l.do(ctx, http.MethodHead, item)
+	if errors.Is(err, errMethodNotAllowed) {
+		resp, err = l.do(ctx, http.MethodGet, item)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Only the headers are needed; closing unread drops the connection, which
+	// is cheaper than downloading the file.
+	defer resp.Body.Close()
+
+	return &Info{
+		ContentType:   resp.Header.Get("Content-Type"),
+		ContentLength: resp.ContentLength,
+	}, nil
+}
+
+func (l *httpLoader) clientFor(item *models.MediaItem) *http.Client {
+	if item.PublicOnly {
+		return l.public
+	}
+	return l.client
+}
+
+var errMethodNotAllowed = errors.New("method not allowed")
+
+// do sends a bodiless request for the item with its download headers and
+// fails on a non-2xx status. The caller must close the response body.
+func (l *httpLoader) do(ctx context.Context, method string, item *models.MediaItem) (*http.Response, error) {
 	if item == nil || item.Url == "" {
-		return 0, fmt.Errorf("empty url")
+		return nil, fmt.Errorf("empty url")
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodHead, item.Url, nil)
+	req, err := http.NewRequestWithContext(ctx, method, item.Url, nil)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	for k, v := range item.DownloadHeaders {
 		req.Header.Set(k, v)
 	}
 
-	resp, err := l.client.Do(req)
+	resp, err := l.clientFor(item).Do(req)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented {
+		resp.Body.Close()
+		return nil, fmt.Errorf("source returned %s: %w", resp.Status, errMethodNotAllowed)
 	}
-	defer resp.Body.Close()
 	if resp.StatusCode/100 != 2 {
-		return 0, fmt.Errorf("source returned %s", resp.Status)
+		resp.Body.Close()
+		return nil, &StatusError{StatusCode: resp.StatusCode, Status: resp.Status}
 	}
 
-	return resp.ContentLength, nil
+	return resp, nil
 }
@@ -1,12 +1,14 @@
 package media_test
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/sxwebdev/downloaderbot/internal/media"
 	"github.com/sxwebdev/downloaderbot/internal/models"
+	"github.com/sxwebdev/downloaderbot/internal/util"
 )
 
 func TestDirectURL(t *testing.T) {
@@ -103,3 +105,60 @@ func TestContentLength(t *testing.T) {
 		}
 	})
 }
+
+func TestProbe(t *testing.T) {
+	var methods []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		// Some CDNs refuse HEAD.
+		if r.URL.Path == "/no-head.jpg" && r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Header().Set("Content-Length", "1024")
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(make([]byte, 1024))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	loader := media.NewHTTPLoader()
+
+	for _, path := range []string{"/photo.jpg", "/no-head.jpg"} {
+		t.Run(path, func(t *testing.T) {
+			methods = nil
+			info, err := loader.Probe(t.Context(), &models.MediaItem{Url: srv.URL + path})
+			if err != nil {
+				t.Fatalf("Probe: %v", err)
+			}
+			if info.ContentType != "image/jpeg" || info.ContentLength != 1024 {
+				t.Fatalf("info = %+v", info)
+			}
+			if methods[0] != http.MethodHead {
+				t.Fatalf("methods = %v, want HEAD first", methods)
+			}
+		})
+	}
+}
+
+func TestOpen_PublicOnly(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("media"))
+	}))
+	t.Cleanup(srv.Close)
+
+	loader := media.NewHTTPLoader()
+
+	content, err := loader.Open(t.Context(), &models.MediaItem{Url: srv.URL + "/clip.mp4"})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	content.Body.Close()
+
+	_, err = loader.Open(t.Context(), &models.MediaItem{Url: srv.URL + "/clip.mp4", PublicOnly: true})
+	if !errors.Is(err, util.ErrPrivateAddress) {
+		t.Fatalf("want ErrPrivateAddress for a public-only item, got %v", err)
+	}
+}
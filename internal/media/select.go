@@ -0,0 +1,106 @@
+package media
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/sxwebdev/downloaderbot/internal/models"
+)
+
+// Candidates returns the variants of item that can be delivered as is, best
+// first: for a video the ones with both video and audio, ranked by height,
+// then bitrate, then size. Variants keep their order among equals, which is
+// the extractor's own preference. Items without variants have their single
+// format as the only candidate.
+func Candidates(item *models.MediaItem) []*models.MediaItem {
+	if len(item.Variants) == 0 {
+		return []*models.MediaItem{item}
+	}
+
+	variants := make([]*models.Variant, 0, len(item.Variants))
+	for _, v := range item.Variants {
+		if !item.Type.IsVideo() || (v.HasVideo && v.HasAudio) {
+			variants = append(variants, v)
+		}
+	}
+	if len(variants) == 0 {
+		// Nothing playable as is: keep the default format of the item.
+		return []*models.MediaItem{item}
+	}
+
+	slices.SortStableFunc(variants, func(a, b *models.Variant) int {
+		return cmp.Or(
+			cmp.Compare(b.Height, a.Height),
+			cmp.Compare(b.Bitrate, a.Bitrate),
+			cmp.Compare(b.ContentLength, a.ContentLength),
+		)
+	})
+
+	candidates := make([]*models.MediaItem, len(variants))
+	for i, v := range variants {
+		candidates[i] = item.WithVariant(v)
+	}
+	return candidates
+}
+
+// probeTimeout bounds each size lookup of Select, so that a slow CDN can't eat
+// the budget of an inline query.
+const probeTimeout = 3 * time.Second
+
+// Select picks the best candidate of item (see Candidates) no larger than
+// limit bytes. Sizes the extractor didn't report are asked with
+// loader.ContentLength, all at once. A source that answers without a size
+// counts as fitting, since refusing every such file would be the worse guess;
+// a candidate whose lookup fails (an error status, a timeout) is skipped. fits
+// is false when no candidate is known to be usable within limit, in which case
+// the best one is returned for the caller to link to instead.
+func Select(ctx context.Context, loader Loader, item *models.MediaItem, limit int64) (selected *models.MediaItem, fits bool) {
+	candidates := Candidates(item)
+	sizes := probeSizes(ctx, loader, candidates, limit)
+	for i, candidate := range candidates {
+		if sizes[i].err == nil && sizes[i].size <= limit {
+			return candidate, true
+		}
+	}
+	return candidates[0], false
+}
+
+type probedSize struct {
+	size int64
+	err  error
+}
+
+// probeSizes returns the size of the candidates, looking up in parallel the
+// ones the extractor didn't report. Candidates ranked below one already known
+// to fit within limit are not looked up. A source rejecting HEAD is reachable
+// but won't tell the size, which is reported as unknown (-1) rather than an
+// error.
+func probeSizes(ctx context.Context, loader Loader, candidates []*models.MediaItem, limit int64) []probedSize {
+	sizes := make([]probedSize, len(candidates))
+	var wg sync.WaitGroup
+	for i, candidate := range candidates {
+		if candidate.ContentLength > 0 {
+			sizes[i].size = candidate.ContentLength
+			if candidate.ContentLength <= limit {
+				break
+			}
+			continue
+		}
+		wg.Go(func() {
+			ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+			defer cancel()
+
+			size, err := loader.ContentLength(ctx, candidate)
+			if errors.Is(err, errMethodNotAllowed) {
+				size, err = -1, nil
+			}
+			sizes[i] = probedSize{size: size, err: err}
+		})
+	}
+	wg.Wait()
+	return sizes
+}
@@ -0,0 +1,147 @@
+package media_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/sxwebdev/downloaderbot/internal/media"
+	"github.com/sxwebdev/downloaderbot/internal/models"
+)
+
+// sizeLoader reports sizes by URL and counts the lookups; URLs it doesn't know
+// fail like a deleted file.
+type sizeLoader struct {
+	media.Loader
+	sizes map[string]int64
+
+	mu     sync.Mutex
+	probed []string
+}
+
+func (l *sizeLoader) ContentLength(_ context.Context, item *models.MediaItem) (int64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.probed = append(l.probed, item.Url)
+	size, ok := l.sizes[item.Url]
+	if !ok {
+		return 0, &media.StatusError{StatusCode: http.StatusNotFound, Status: "404 Not Found"}
+	}
+	return size, nil
+}
+
+func TestCandidates(t *testing.T) {
+	item := &models.MediaItem{
+		Id:       "v",
+		Type:     models.MediaTypeVideo,
+		Url:      "360.mp4",
+		Duration: 60,
+		Variants: []*models.Variant{
+			{Url: "360.mp4", Height: 360, HasVideo: true, HasAudio: true},
+			{Url: "1080.mp4", Height: 1080, HasVideo: true},
+			{Url: "720-low.mp4", Height: 720, Bitrate: 1000, HasVideo: true, HasAudio: true},
+			{Url: "720-high.mp4", Height: 720, Bitrate: 2000, HasVideo: true, HasAudio: true},
+			{Url: "audio.m4a", HasAudio: true},
+		},
+	}
+
+	got := media.Candidates(item)
+	// The 1080p format has no audio track and the audio one no picture: only
+	// the formats playable as is are candidates.
+	want := []string{"720-high.mp4", "720-low.mp4", "360.mp4"}
+	if len(got) != len(want) {
+		t.Fatalf("got %d candidates, want %d", len(got), len(want))
+	}
+	for i, c := range got {
+		if c.Url != want[i] {
+			t.Fatalf("candidate %d = %s, want %s", i, c.Url, want[i])
+		}
+		if c.Id != item.Id || c.Duration != item.Duration || c.Type != models.MediaTypeVideo || c.VideoWithoutAudio {
+			t.Fatalf("candidate must keep the item identity: %+v", c)
+		}
+	}
+	if item.Url != "360.mp4" {
+		t.Fatal("the item must stay unchanged")
+	}
+
+	plain := &models.MediaItem{Type: models.MediaTypePhoto, Url: "p.jpg"}
+	if got := media.Candidates(plain); len(got) != 1 || got[0] != plain {
+		t.Fatal("an item without variants must be its only candidate")
+	}
+}
+
+func TestSelect(t *testing.T) {
+	item := &models.MediaItem{
+		Type: models.MediaTypeVideo,
+		Variants: []*models.Variant{
+			{Url: "1080.mp4", Height: 1080, ContentLength: 90, HasVideo: true, HasAudio: true},
+			{Url: "720.mp4", Height: 720, HasVideo: true, HasAudio: true},
+			{Url: "480.mp4", Height: 480, ContentLength: 20, HasVideo: true, HasAudio: true},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		sizes      map[string]int64
+		limit      int64
+		wantURL    string
+		wantFits   bool
+		wantProbed int
+	}{
+		{name: "best fits without probing", limit: 100, wantURL: "1080.mp4", wantFits: true},
+		{name: "probed size fits", sizes: map[string]int64{"720.mp4": 50}, limit: 50, wantURL: "720.mp4", wantFits: true, wantProbed: 1},
+		{name: "probed size too large", sizes: map[string]int64{"720.mp4": 51}, limit: 50, wantURL: "480.mp4", wantFits: true, wantProbed: 1},
+		{name: "unknown size counts as fitting", sizes: map[string]int64{"720.mp4": -1}, limit: 50, wantURL: "720.mp4", wantFits: true, wantProbed: 1},
+		{name: "failed probe is skipped", limit: 50, wantURL: "480.mp4", wantFits: true, wantProbed: 1},
+		{name: "nothing usable", limit: 10, wantURL: "1080.mp4", wantFits: false, wantProbed: 1},
+		{name: "nothing fits", sizes: map[string]int64{"720.mp4": 60}, limit: 10, wantURL: "1080.mp4", wantFits: false, wantProbed: 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			loader := &sizeLoader{sizes: tc.sizes}
+			got, fits := media.Select(t.Context(), loader, item, tc.limit)
+			if got.Url != tc.wantURL || fits != tc.wantFits {
+				t.Fatalf("Select = %s, %v; want %s, %v", got.Url, fits, tc.wantURL, tc.wantFits)
+			}
+			if len(loader.probed) != tc.wantProbed {
+				t.Fatalf("probed %v, want %d lookups", loader.probed, tc.wantProbed)
+			}
+		})
+	}
+}
+
+func TestSelect_Probes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/no-head.mp4":
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		case "/forbidden.mp4":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.Header().Set("Content-Length", "100")
+		}
+	}))
+	defer srv.Close()
+
+	loader := media.NewHTTPLoader(media.WithHTTPClient(srv.Client()))
+	variants := func(urls ...string) *models.MediaItem {
+		item := &models.MediaItem{Type: models.MediaTypeVideo}
+		for i, u := range urls {
+			item.Variants = append(item.Variants, &models.Variant{Url: srv.URL + u, Height: 1080 - i, HasVideo: true, HasAudio: true})
+		}
+		return item
+	}
+
+	got, fits := media.Select(t.Context(), loader, variants("/forbidden.mp4", "/ok.mp4"), 100)
+	if got.Url != srv.URL+"/ok.mp4" || !fits {
+		t.Fatalf("Select = %s, %v; want the variant after the forbidden one", got.Url, fits)
+	}
+
+	got, fits = media.Select(t.Context(), loader, variants("/no-head.mp4", "/ok.mp4"), 10)
+	if got.Url != srv.URL+"/no-head.mp4" || !fits {
+		t.Fatalf("Select = %s, %v; a source rejecting HEAD must count as fitting", got.Url, fits)
+	}
+}
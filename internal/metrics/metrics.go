@@ -8,12 +8,16 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
 )
 
 const (
 	OutcomeSuccess = "success"
 	OutcomeFailure = "failure"
 
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+
 	ReasonNone       = "none"
 	ReasonTimeout    = "timeout"
 	ReasonCanceled   = "canceled"
@@ -23,8 +27,32 @@ const (
 	ReasonSizeLimit  = "size_limit"
 	ReasonTelegram   = "telegram"
 	ReasonOther      = "other"
+
+	// Reasons for extraction errors of the pkg/extractor taxonomy.
+	ReasonUnsupportedURL  = "unsupported_url"
+	ReasonNotSupported    = "not_supported"
+	ReasonBlocked         = "blocked"
+	ReasonPrivate         = "private"
+	ReasonNotFound        = "not_found"
+	ReasonLoginRequired   = "login_required"
+	ReasonRateLimited     = "rate_limited"
+	ReasonGeoBlocked      = "geo_blocked"
+	ReasonUpstreamChanged = "upstream_changed"
 )
 
+// extractorReasons labels the pkg/extractor error taxonomy.
+var extractorReasons = map[error]string{
+	extractor.ErrUnsupportedURL:  ReasonUnsupportedURL,
+	extractor.ErrNotSupported:    ReasonNotSupported,
+	extractor.ErrBlocked:         ReasonBlocked,
+	extractor.ErrPrivate:         ReasonPrivate,
+	extractor.ErrNotFound:        ReasonNotFound,
+	extractor.ErrLoginRequired:   ReasonLoginRequired,
+	extractor.ErrRateLimited:     ReasonRateLimited,
+	extractor.ErrGeoBlocked:      ReasonGeoBlocked,
+	extractor.ErrUpstreamChanged: ReasonUpstreamChanged,
+}
+
 var (
 	InlineRequests = prometheus.NewCounter(prometheus.CounterOpts{
 		Name: "inline_requests_total",
@@ -60,8 +88,8 @@ var (
 
 	MediaExtractionAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Name: "media_extraction_attempts_total",
-		Help: "Number of media extraction attempts, including retries.",
-	}, []string{"source", "outcome", "reason"})
+		Help: "Number of media extraction attempts, including retries and fallback extractors.",
+	}, []string{"source", "extractor", "outcome", "reason"})
 
 	MediaExtractionRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Name: "media_extraction_requests_total",
@@ -87,6 +115,52 @@ var (
 		Name: "telegram_deliveries_total",
 		Help: "Final outcomes of media delivery operations to Telegram after retries.",
 	}, []string{"source", "kind", "outcome", "reason"})
+
+	MediaCacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "media_cache_lookups_total",
+		Help: "Extraction result cache lookups by source and result (hit or miss).",
+	}, []string{"source", "result"})
+
+	CanaryChecks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "canary_checks_total",
+		Help: "Extractions of canary links by source, outcome and reason.",
+	}, []string{"source", "outcome", "reason"})
+
+	CanaryConsecutiveFailures = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "canary_consecutive_failures",
+		Help: "Canary rounds a source has failed in a row.",
+	}, []string{"source"})
+
+	SourceHealthy = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "source_healthy",
+		Help: "Whether a source passes its canary checks (1) or is degraded (0).",
+	}, []string{"source"})
+
+	CircuitBreakerState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "circuit_breaker_state",
+		Help: "State of the circuit breaker of a source: 0 closed, 1 half-open, 2 open.",
+	}, []string{"source"})
+
+	InstagramFetchAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "instagram_fetch_attempts_total",
+		Help: "Instagram post fetches by strategy, outcome and reason.",
+	}, []string{"strategy", "outcome", "reason"})
+
+	InstagramFetchDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "instagram_fetch_duration_seconds",
+		Help:    "Duration of Instagram post fetches by strategy.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"strategy"})
+
+	InstagramFetchesServed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "instagram_fetches_served_total",
+		Help: "Instagram post requests by the strategy that served them, none when all failed.",
+	}, []string{"strategy"})
+
+	InstagramStrategyHealthy = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "instagram_strategy_healthy",
+		Help: "Whether an Instagram fetch strategy is in use (1) or skipped as broken (0).",
+	}, []string{"strategy"})
 )
 
 func init() {
@@ -103,18 +177,29 @@ func init() {
 		MediaDownloadBytes,
 		MediaDownloadCompletedBytes,
 		TelegramDeliveries,
+		MediaCacheLookups,
+		CanaryChecks,
+		CanaryConsecutiveFailures,
+		SourceHealthy,
+		CircuitBreakerState,
+		InstagramFetchAttempts,
+		InstagramFetchDuration,
+		InstagramFetchesServed,
+		InstagramStrategyHealthy,
 		processActiveUsers,
 	)
 }
 
 // ObserveExtractionAttempt records one extractor call. Calls made by retries
 // are deliberately counted separately from the final logical request result.
-func ObserveExtractionAttempt(source string, started time.Time, err error) {
+// extractor names the extractor that was tried, which differs from source when
+// a fallback extractor of the host's chain served (or failed) the request.
+func ObserveExtractionAttempt(source, extractor string, started time.Time, err error) {
 	source = normalizeSource(source)
 	ExtractDuration.WithLabelValues(source).Observe(time.Since(started).Seconds())
 
 	outcome, reason := outcomeAndReason(err, ReasonOther)
-	MediaExtractionAttempts.WithLabelValues(source, outcome, reason).Inc()
+	MediaExtractionAttempts.WithLabelValues(source, normalizeSource(extractor), outcome, reason).Inc()
 	if err != nil {
 		ExtractErrors.WithLabelValues(source, reason).Inc()
 	}
@@ -127,6 +212,73 @@ func ObserveExtractionRequest(source string, err error) {
 	MediaExtractionRequests.WithLabelValues(normalizeSource(source), outcome, reason).Inc()
 }
 
+// ObserveCacheLookup records whether the extraction result cache had the
+// media of a request.
+func ObserveCacheLookup(source string, hit bool) {
+	result := CacheMiss
+	if hit {
+		result = CacheHit
+	}
+	MediaCacheLookups.WithLabelValues(normalizeSource(source), result).Inc()
+}
+
+// ObserveCanaryCheck records the extraction of one canary link.
+func ObserveCanaryCheck(source string, err error) {
+	outcome, reason := outcomeAndReason(err, ReasonOther)
+	CanaryChecks.WithLabelValues(normalizeSource(source), outcome, reason).Inc()
+}
+
+// SetSourceHealth records the canary state of a source after a round.
+func SetSourceHealth(source string, failures int, degraded bool) {
+	source = normalizeSource(source)
+	CanaryConsecutiveFailures.WithLabelValues(source).Set(float64(failures))
+	healthy := 1.0
+	if degraded {
+		healthy = 0
+	}
+	SourceHealthy.WithLabelValues(source).Set(healthy)
+}
+
+// SetCircuitBreakerState records the state of the circuit breaker of a
+// source, as the values documented on CircuitBreakerState.
+func SetCircuitBreakerState(source string, state int) {
+	CircuitBreakerState.WithLabelValues(normalizeSource(source)).Set(float64(state))
+}
+
+// InstagramFetchObserver records the work of an instagram.ChainFetcher.
+type InstagramFetchObserver struct{}
+
+// ObserveAttempt records one fetch of a strategy.
+func (InstagramFetchObserver) ObserveAttempt(strategy string, started time.Time, err error) {
+	InstagramFetchDuration.WithLabelValues(strategy).Observe(time.Since(started).Seconds())
+	outcome, reason := outcomeAndReason(err, ReasonOther)
+	InstagramFetchAttempts.WithLabelValues(strategy, outcome, reason).Inc()
+}
+
+// ObserveServed records the strategy that served a request, "" for none.
+func (InstagramFetchObserver) ObserveServed(strategy string) {
+	if strategy == "" {
+		strategy = ReasonNone
+	}
+	InstagramFetchesServed.WithLabelValues(strategy).Inc()
+}
+
+// SetHealthy records whether a strategy is in use or skipped as broken.
+func (InstagramFetchObserver) SetHealthy(strategy string, healthy bool) {
+	value := 0.0
+	if healthy {
+		value = 1
+	}
+	InstagramStrategyHealthy.WithLabelValues(strategy).Set(value)
+}
+
+// ExtractionReason returns the reason label media_extraction_attempts_total
+// gives an extraction error, ReasonNone for nil.
+func ExtractionReason(err error) string {
+	_, reason := outcomeAndReason(err, ReasonOther)
+	return reason
+}
+
 // ObserveDownloadFailure records a media object that the bot could not or did
 // not download. reason is normalized to a bounded enum before becoming a label.
 func ObserveDownloadFailure(source, reason string) {
@@ -219,9 +371,12 @@ func outcomeAndReason(err error, fallbackReason string) (string, string) {
 		return OutcomeFailure, ReasonTimeout
 	case errors.Is(err, context.Canceled):
 		return OutcomeFailure, ReasonCanceled
-	default:
-		return OutcomeFailure, normalizeReason(fallbackReason)
 	}
+
+	if reason, ok := extractorReasons[extractor.Kind(err)]; ok {
+		return OutcomeFailure, reason
+	}
+	return OutcomeFailure, normalizeReason(fallbackReason)
 }
 
 func normalizeSource(source string) string {
@@ -241,7 +396,16 @@ func normalizeReason(reason string) string {
 		ReasonIncomplete,
 		ReasonSizeLimit,
 		ReasonTelegram,
-		ReasonOther:
+		ReasonOther,
+		ReasonUnsupportedURL,
+		ReasonNotSupported,
+		ReasonBlocked,
+		ReasonPrivate,
+		ReasonNotFound,
+		ReasonLoginRequired,
+		ReasonRateLimited,
+		ReasonGeoBlocked,
+		ReasonUpstreamChanged:
 		return reason
 	default:
 		return ReasonOther
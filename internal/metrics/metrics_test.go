@@ -3,6 +3,7 @@ package metrics_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"strings"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	appmetrics "github.com/sxwebdev/downloaderbot/internal/metrics"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
 )
 
 func TestTrackDownloadComplete(t *testing.T) {
@@ -144,36 +146,80 @@ func TestExtractionAndDeliveryOutcomes(t *testing.T) {
 	t.Run("successful attempt and request are separate", func(t *testing.T) {
 		const source = "test_extraction_success"
 		attemptBefore := counterValue(t, appmetrics.MediaExtractionAttempts.WithLabelValues(
-			source, appmetrics.OutcomeSuccess, appmetrics.ReasonNone,
+			source, source, appmetrics.OutcomeSuccess, appmetrics.ReasonNone,
 		))
 		requestBefore := counterValue(t, appmetrics.MediaExtractionRequests.WithLabelValues(
 			source, appmetrics.OutcomeSuccess, appmetrics.ReasonNone,
 		))
 
-		appmetrics.ObserveExtractionAttempt(source, time.Now(), nil)
+		appmetrics.ObserveExtractionAttempt(source, source, time.Now(), nil)
 		appmetrics.ObserveExtractionRequest(source, nil)
 
 		assertCounterDelta(t, appmetrics.MediaExtractionAttempts.WithLabelValues(
-			source, appmetrics.OutcomeSuccess, appmetrics.ReasonNone,
+			source, source, appmetrics.OutcomeSuccess, appmetrics.ReasonNone,
 		), attemptBefore, 1)
 		assertCounterDelta(t, appmetrics.MediaExtractionRequests.WithLabelValues(
 			source, appmetrics.OutcomeSuccess, appmetrics.ReasonNone,
 		), requestBefore, 1)
 	})
 
+	t.Run("fallback attempt is labeled with the extractor that served it", func(t *testing.T) {
+		const (
+			source    = "test_extraction_fallback"
+			extractor = "lux-test_extraction_fallback"
+		)
+		primaryBefore := counterValue(t, appmetrics.MediaExtractionAttempts.WithLabelValues(
+			source, source, appmetrics.OutcomeFailure, appmetrics.ReasonOther,
+		))
+		fallbackBefore := counterValue(t, appmetrics.MediaExtractionAttempts.WithLabelValues(
+			source, extractor, appmetrics.OutcomeSuccess, appmetrics.ReasonNone,
+		))
+
+		appmetrics.ObserveExtractionAttempt(source, source, time.Now(), errors.New("blocked"))
+		appmetrics.ObserveExtractionAttempt(source, extractor, time.Now(), nil)
+
+		assertCounterDelta(t, appmetrics.MediaExtractionAttempts.WithLabelValues(
+			source, source, appmetrics.OutcomeFailure, appmetrics.ReasonOther,
+		), primaryBefore, 1)
+		assertCounterDelta(t, appmetrics.MediaExtractionAttempts.WithLabelValues(
+			source, extractor, appmetrics.OutcomeSuccess, appmetrics.ReasonNone,
+		), fallbackBefore, 1)
+	})
+
+	t.Run("typed extraction error gets its own reason", func(t *testing.T) {
+		const source = "test_extraction_typed"
+		attemptBefore := counterValue(t, appmetrics.MediaExtractionAttempts.WithLabelValues(
+			source, source, appmetrics.OutcomeFailure, appmetrics.ReasonPrivate,
+		))
+		requestBefore := counterValue(t, appmetrics.MediaExtractionRequests.WithLabelValues(
+			source, appmetrics.OutcomeFailure, appmetrics.ReasonPrivate,
+		))
+
+		err := fmt.Errorf("failed to get post: %w: %w", extractor.ErrPrivate, errors.New("account is private"))
+		appmetrics.ObserveExtractionAttempt(source, source, time.Now(), err)
+		appmetrics.ObserveExtractionRequest(source, fmt.Errorf("failed to get media from source: %w", errors.Join(err)))
+
+		assertCounterDelta(t, appmetrics.MediaExtractionAttempts.WithLabelValues(
+			source, source, appmetrics.OutcomeFailure, appmetrics.ReasonPrivate,
+		), attemptBefore, 1)
+		assertCounterDelta(t, appmetrics.MediaExtractionRequests.WithLabelValues(
+			source, appmetrics.OutcomeFailure, appmetrics.ReasonPrivate,
+		), requestBefore, 1)
+	})
+
 	t.Run("canceled attempt updates new and compatibility metrics", func(t *testing.T) {
 		const source = "test_extraction_canceled"
 		attemptBefore := counterValue(t, appmetrics.MediaExtractionAttempts.WithLabelValues(
-			source, appmetrics.OutcomeFailure, appmetrics.ReasonCanceled,
+			source, source, appmetrics.OutcomeFailure, appmetrics.ReasonCanceled,
 		))
 		compatBefore := counterValue(t, appmetrics.ExtractErrors.WithLabelValues(
 			source, appmetrics.ReasonCanceled,
 		))
 
-		appmetrics.ObserveExtractionAttempt(source, time.Now(), context.Canceled)
+		appmetrics.ObserveExtractionAttempt(source, source, time.Now(), context.Canceled)
 
 		assertCounterDelta(t, appmetrics.MediaExtractionAttempts.WithLabelValues(
-			source, appmetrics.OutcomeFailure, appmetrics.ReasonCanceled,
+			source, source, appmetrics.OutcomeFailure, appmetrics.ReasonCanceled,
 		), attemptBefore, 1)
 		assertCounterDelta(t, appmetrics.ExtractErrors.WithLabelValues(
 			source, appmetrics.ReasonCanceled,
@@ -256,3 +302,16 @@ func assertCounterDelta(t *testing.T, collector prometheus.Collector, before flo
 		t.Errorf("counter delta = %v, want %d", got, want)
 	}
 }
+
+func TestObserveCacheLookup(t *testing.T) {
+	const source = "test_cache"
+	hitsBefore := counterValue(t, appmetrics.MediaCacheLookups.WithLabelValues(source, appmetrics.CacheHit))
+	missesBefore := counterValue(t, appmetrics.MediaCacheLookups.WithLabelValues(source, appmetrics.CacheMiss))
+
+	appmetrics.ObserveCacheLookup(source, false)
+	appmetrics.ObserveCacheLookup(source, true)
+	appmetrics.ObserveCacheLookup(source, true)
+
+	assertCounterDelta(t, appmetrics.MediaCacheLookups.WithLabelValues(source, appmetrics.CacheHit), hitsBefore, 2)
+	assertCounterDelta(t, appmetrics.MediaCacheLookups.WithLabelValues(source, appmetrics.CacheMiss), missesBefore, 1)
+}
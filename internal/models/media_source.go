@@ -50,4 +50,10 @@ const (
 	MediaSourceXvideos      MediaSource = "xvideos"
 	MediaSourceYinyuetai    MediaSource = "yinyuetai"
 	MediaSourceZingmp3      MediaSource = "zingmp3"
+
+	// MediaSourceGeneric labels media read from the preview metadata of a page
+	// on a host no dedicated extractor handles.
+	MediaSourceGeneric MediaSource = "generic"
+	// MediaSourceDirect labels links straight to a media file.
+	MediaSourceDirect MediaSource = "direct"
 )
@@ -14,8 +14,9 @@ type Owner struct {
 	IsVerified        bool   `json:"is_verified"`
 }
 
-// MediaItem contains information about the Instagram post
-// which is similar to the Instagram Media struct
+// MediaItem is one entry of the media: a video, a photo, a carousel slide. Its
+// Url, Quality, MimeType, ContentLength and size describe the format delivered
+// by default; Variants lists every format the entry is available in.
 type MediaItem struct {
 	Id                string    `json:"id"`
 	Shortcode         string    `json:"shortcode"`
@@ -35,27 +36,118 @@ type MediaItem struct {
 	// Telegram inline video results carry a mandatory thumbnail_url that must be
 	// a JPEG — the media URL itself is not a valid value for it.
 	ThumbnailUrl string `json:"thumbnail_url,omitempty"`
+	// Title and Artist name the track of an audio item, empty when unknown.
+	Title  string `json:"title,omitempty"`
+	Artist string `json:"artist,omitempty"`
 	// DownloadHeaders are extra HTTP headers required to download Url (e.g.
 	// TikTok CDN needs Referer + Cookie). Empty for sources whose URLs are
 	// publicly fetchable. Downloading is handled by internal/media.Loader.
 	DownloadHeaders map[string]string `json:"-"`
+	// PublicOnly restricts downloads of the item to public addresses. It is
+	// set on items of links from unknown hosts, which may point anywhere.
+	PublicOnly bool `json:"-"`
+	// Variants are the formats the entry is available in (qualities, codecs,
+	// an audio-only track), the default one included. Empty when the source
+	// offers a single format, the one the item describes.
+	Variants []*Variant `json:"variants,omitempty"`
+}
+
+// Variant is one format a media entry is available in.
+type Variant struct {
+	Url      string `json:"url"`
+	Quality  string `json:"quality"`
+	MimeType string `json:"mime_type"`
+	// Codec is the codecs parameter of the format, like "avc1.64001F,
+	// mp4a.40.2", empty when unknown.
+	Codec string `json:"codec,omitempty"`
+	// Bitrate is in bits per second, 0 when unknown.
+	Bitrate       int   `json:"bitrate,omitempty"`
+	Width         int   `json:"width,omitempty"`
+	Height        int   `json:"height,omitempty"`
+	ContentLength int64 `json:"content_length"`
+	HasVideo      bool  `json:"has_video"`
+	HasAudio      bool  `json:"has_audio"`
+}
+
+// WithVariant returns a copy of the item describing variant v instead of its
+// default format. The copy keeps the item's identity, duration, thumbnail and
+// download headers; an audio-only variant turns it into an audio item.
+func (i *MediaItem) WithVariant(v *Variant) *MediaItem {
+	c := *i
+	c.Url = v.Url
+	c.Quality = v.Quality
+	c.MimeType = v.MimeType
+	c.ContentLength = v.ContentLength
+	c.VideoWithoutAudio = v.HasVideo && !v.HasAudio
+	if v.Width > 0 && v.Height > 0 {
+		c.Width, c.Height = v.Width, v.Height
+	}
+	if !v.HasVideo && v.HasAudio {
+		c.Type = MediaTypeAudio
+	}
+	return &c
+}
+
+// AllVariants returns the formats of the item: its Variants, or the single
+// format the item describes when it lists none.
+func (i *MediaItem) AllVariants() []*Variant {
+	if len(i.Variants) > 0 {
+		return i.Variants
+	}
+	return []*Variant{{
+		Url:           i.Url,
+		Quality:       i.Quality,
+		MimeType:      i.MimeType,
+		Width:         i.Width,
+		Height:        i.Height,
+		ContentLength: i.ContentLength,
+		HasVideo:      i.Type.IsVideo(),
+		HasAudio:      i.Type.IsAudio() || (i.Type.IsVideo() && !i.VideoWithoutAudio),
+	}}
 }
 
 // Media which contains a single Instagram post
 type Media struct {
-	Source     MediaSource  `json:"source"`
-	RequestUrl string       `json:"request_url"`
-	Id         string       `json:"id"`
-	Shortcode  string       `json:"shortcode"`
-	Title      string       `json:"title"`
-	Author     string       `json:"author"`
-	Type       string       `json:"type"`
-	Comments   uint64       `json:"comments_count"`
-	Likes      uint64       `json:"likes_count"`
-	Caption    string       `json:"caption"`
-	Url        string       `json:"url"`
-	Items      []*MediaItem `json:"items"`
-	TakenAt    int64        `json:"taken_at"` // Timestamp
+	Source     MediaSource `json:"source"`
+	RequestUrl string      `json:"request_url"`
+	Id         string      `json:"id"`
+	Shortcode  string      `json:"shortcode"`
+	Title      string      `json:"title"`
+	Author     string      `json:"author"`
+	// Owner details the author, nil when the source doesn't tell more than
+	// its name.
+	Owner    *Owner       `json:"owner,omitempty"`
+	Type     string       `json:"type"`
+	Comments uint64       `json:"comments_count"`
+	Likes    uint64       `json:"likes_count"`
+	Caption  string       `json:"caption"`
+	Url      string       `json:"url"`
+	Items    []*MediaItem `json:"items"`
+	TakenAt  int64        `json:"taken_at"` // Timestamp
+}
+
+// Clone returns a copy of the media, its items and their variants, so that the
+// copy can be modified without affecting the original. DownloadHeaders maps
+// are shared: they are never modified once extracted.
+func (m *Media) Clone() *Media {
+	c := *m
+	if m.Owner != nil {
+		owner := *m.Owner
+		c.Owner = &owner
+	}
+	c.Items = make([]*MediaItem, len(m.Items))
+	for i, item := range m.Items {
+		itemCopy := *item
+		if item.Variants != nil {
+			itemCopy.Variants = make([]*Variant, len(item.Variants))
+			for j, v := range item.Variants {
+				variantCopy := *v
+				itemCopy.Variants[j] = &variantCopy
+			}
+		}
+		c.Items[i] = &itemCopy
+	}
+	return &c
 }
 
 // FromEmbedResponse will automatically transforms the EmbedResponse to the Media
@@ -63,6 +155,7 @@ func FromEmbedResponse(embed response.EmbedResponse) Media {
 	media := Media{
 		Id:        embed.Media.Id,
 		Shortcode: embed.Media.Shortcode,
+		Author:    embed.Media.Owner.Username,
 		Type:      embed.Media.Type,
 		Comments:  embed.Media.Comments.Count,
 		Likes:     embed.Media.Likes.Count,
@@ -71,6 +164,17 @@ func FromEmbedResponse(embed response.EmbedResponse) Media {
 		Caption:   embed.GetCaption(),
 	}
 
+	if owner := embed.Media.Owner; owner.Username != "" {
+		media.Owner = &Owner{
+			Id:                owner.Id,
+			ProfilePictureURL: owner.ProfilePictureURL,
+			Username:          owner.Username,
+			Followers:         owner.Followers.Count,
+			IsPrivate:         owner.IsPrivate,
+			IsVerified:        owner.IsVerified,
+		}
+	}
+
 	for _, item := range embed.Media.SliderItems.Edges {
 		mediaType := MediaTypePhoto
 		if item.Node.IsVideo {
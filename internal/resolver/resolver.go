@@ -0,0 +1,242 @@
+// Package resolver expands shortened and redirecting links (t.co, bit.ly,
+// goo.gl, lnkd.in, ...) into the URL they point at, so the extractor registry
+// can be consulted with a host it knows.
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/sxwebdev/downloaderbot/internal/util"
+)
+
+const (
+	defaultMaxHops   = 5
+	defaultTimeout   = 10 * time.Second
+	defaultCacheTTL  = 24 * time.Hour
+	defaultCacheSize = 10000
+)
+
+// ErrTooManyHops is returned when the link still redirects after the hop limit.
+var ErrTooManyHops = errors.New("too many redirects")
+
+// KnownFunc reports whether the link can be handed to an extractor as it is,
+// which ends the resolution.
+type KnownFunc func(u *url.URL) bool
+
+// Resolver follows HTTP redirects until it reaches a link KnownFunc accepts.
+// Resolutions are cached, so a popular short link costs one round of requests.
+type Resolver struct {
+	client    *http.Client
+	known     KnownFunc
+	maxHops   int
+	timeout   time.Duration
+	cacheTTL  time.Duration
+	cacheSize int
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	url     string
+	expires time.Time
+}
+
+// Option configures a Resolver.
+type Option func(*Resolver)
+
+// WithMaxHops sets how many redirects are followed before giving up.
+func WithMaxHops(n int) Option {
+	return func(r *Resolver) { r.maxHops = n }
+}
+
+// WithTimeout sets the time budget of a whole resolution, all hops included.
+func WithTimeout(d time.Duration) Option {
+	return func(r *Resolver) { r.timeout = d }
+}
+
+// WithHTTPClient sets the client used for the requests. Its redirect policy is
+// replaced, as the resolver follows redirects itself. The default client only
+// connects to public addresses, since the links come from users.
+func WithHTTPClient(c *http.Client) Option {
+	return func(r *Resolver) { r.client = c }
+}
+
+// WithCache sets how long resolutions are kept and how many of them.
+func WithCache(ttl time.Duration, size int) Option {
+	return func(r *Resolver) {
+		r.cacheTTL = ttl
+		r.cacheSize = size
+	}
+}
+
+// New creates a resolver that stops at the links known accepts.
+func New(known KnownFunc, opts ...Option) *Resolver {
+	r := &Resolver{
+		client:    util.PublicHttpClient(),
+		known:     known,
+		maxHops:   defaultMaxHops,
+		timeout:   defaultTimeout,
+		cacheTTL:  defaultCacheTTL,
+		cacheSize: defaultCacheSize,
+		cache:     make(map[string]cacheEntry),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	// Don't let the client follow redirects: every hop is checked against
+	// known, and a known link is never requested.
+	client := *r.client
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	r.client = &client
+
+	return r
+}
+
+// Resolve returns the URL rawURL ends up at. A known link is returned as it is
+// without any request; otherwise redirects are followed until a known link is
+// reached or a response doesn't redirect, whose URL is then returned.
+func (r *Resolver) Resolve(ctx context.Context, rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse link: %w", err)
+	}
+	if r.known(u) {
+		return rawURL, nil
+	}
+
+	if final, ok := r.cached(rawURL); ok {
+		return final, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	for hops := 0; ; hops++ {
+		next, err := r.next(ctx, u)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve %s: %w", rawURL, err)
+		}
+		if next == nil {
+			break
+		}
+		if hops == r.maxHops {
+			return "", fmt.Errorf("failed to resolve %s: %w", rawURL, ErrTooManyHops)
+		}
+
+		u = next
+		if r.known(u) {
+			break
+		}
+	}
+
+	final := u.String()
+	r.store(rawURL, final)
+
+	return final, nil
+}
+
+// next requests u and returns the redirect target, or nil when the response
+// is not a redirect. HEAD is tried first; servers that refuse it get a GET.
+func (r *Resolver) next(ctx context.Context, u *url.URL) (*url.URL, error) {
+	resp, err := r.do(ctx, http.MethodHead, u)
+	if err != nil {
+		return nil, err
+	}
+	if !isRedirect(resp.StatusCode) && resp.StatusCode >= 400 {
+		resp, err = r.do(ctx, http.MethodGet, u)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !isRedirect(resp.StatusCode) {
+		return nil, nil
+	}
+
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return nil, nil
+	}
+	next, err := u.Parse(location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redirect location %q: %w", location, err)
+	}
+
+	return next, nil
+}
+
+func (r *Resolver) do(ctx context.Context, method string, u *url.URL) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	// Only the status and headers matter; drain a little so the connection
+	// can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+
+	return resp, nil
+}
+
+func isRedirect(status int) bool {
+	switch status {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+	return false
+}
+
+func (r *Resolver) cached(rawURL string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e, ok := r.cache[rawURL]
+	if !ok || time.Now().After(e.expires) {
+		return "", false
+	}
+	return e.url, true
+}
+
+func (r *Resolver) store(rawURL, final string) {
+	if r.cacheSize <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.cache) >= r.cacheSize {
+		now := time.Now()
+		for k, e := range r.cache {
+			if now.After(e.expires) {
+				delete(r.cache, k)
+			}
+		}
+	}
+	// Still full: drop an arbitrary entry rather than grow without bound.
+	for k := range r.cache {
+		if len(r.cache) < r.cacheSize {
+			break
+		}
+		delete(r.cache, k)
+	}
+
+	r.cache[rawURL] = cacheEntry{url: final, expires: time.Now().Add(r.cacheTTL)}
+}
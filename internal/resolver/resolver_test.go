@@ -0,0 +1,126 @@
+package resolver_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sxwebdev/downloaderbot/internal/resolver"
+	"github.com/sxwebdev/downloaderbot/internal/util"
+)
+
+func knownHost(host string) resolver.KnownFunc {
+	return func(u *url.URL) bool { return u.Host == host }
+}
+
+func TestResolve(t *testing.T) {
+	var requests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/short", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Redirect(w, r, "/hop", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/hop", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		// Some shorteners refuse HEAD.
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		http.Redirect(w, r, "https://video.test/watch?v=1", http.StatusFound)
+	})
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	r := resolver.New(knownHost("video.test"), resolver.WithHTTPClient(srv.Client()))
+	ctx := context.Background()
+
+	t.Run("follows redirects to a known host", func(t *testing.T) {
+		got, err := r.Resolve(ctx, srv.URL+"/short")
+		if err != nil {
+			t.Fatalf("Resolve: %v", err)
+		}
+		if got != "https://video.test/watch?v=1" {
+			t.Fatalf("got %s", got)
+		}
+	})
+
+	t.Run("resolutions are cached", func(t *testing.T) {
+		before := requests.Load()
+		if _, err := r.Resolve(ctx, srv.URL+"/short"); err != nil {
+			t.Fatalf("Resolve: %v", err)
+		}
+		if n := requests.Load() - before; n != 0 {
+			t.Fatalf("cached resolution made %d requests", n)
+		}
+	})
+
+	t.Run("known links are not requested", func(t *testing.T) {
+		got, err := r.Resolve(ctx, "https://video.test/watch?v=2")
+		if err != nil || got != "https://video.test/watch?v=2" {
+			t.Fatalf("got %s, %v", got, err)
+		}
+	})
+
+	t.Run("stops at a page that doesn't redirect", func(t *testing.T) {
+		got, err := r.Resolve(ctx, srv.URL+"/page")
+		if err != nil {
+			t.Fatalf("Resolve: %v", err)
+		}
+		if got != srv.URL+"/page" {
+			t.Fatalf("got %s", got)
+		}
+	})
+}
+
+func TestResolve_TooManyHops(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, r.URL.Path+"x", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	r := resolver.New(knownHost("video.test"), resolver.WithMaxHops(3), resolver.WithHTTPClient(srv.Client()))
+	_, err := r.Resolve(context.Background(), srv.URL+"/")
+	if !errors.Is(err, resolver.ErrTooManyHops) {
+		t.Fatalf("want ErrTooManyHops, got %v", err)
+	}
+
+	// Exactly the hop limit is fine.
+	srv2 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.URL.Path) > 3 {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		http.Redirect(w, r, r.URL.Path+"x", http.StatusFound)
+	}))
+	defer srv2.Close()
+
+	got, err := r.Resolve(context.Background(), srv2.URL+"/")
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if got != srv2.URL+"/xxx" {
+		t.Fatalf("got %s", got)
+	}
+}
+
+func TestResolve_PrivateAddress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request %s reached the server", r.URL)
+	}))
+	defer srv.Close()
+
+	r := resolver.New(knownHost("video.test"))
+	_, err := r.Resolve(context.Background(), srv.URL+"/short")
+	if !errors.Is(err, util.ErrPrivateAddress) {
+		t.Fatalf("want ErrPrivateAddress, got %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package parser
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sxwebdev/downloaderbot/internal/config"
+	"github.com/sxwebdev/downloaderbot/internal/metrics"
+	"github.com/sxwebdev/downloaderbot/internal/models"
+)
+
+// CircuitOpenError is returned for requests to a source whose circuit breaker
+// is open. It wraps ErrSourceUnavailable.
+type CircuitOpenError struct {
+	Source models.MediaSource
+	// RetryAt is when the breaker lets a trial request through.
+	RetryAt time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker of %s is open until %s", e.Source, e.RetryAt.Format(time.RFC3339))
+}
+
+func (e *CircuitOpenError) Unwrap() error { return ErrSourceUnavailable }
+
+// breakerState is the state of a circuit breaker, exported as the value of
+// the circuit_breaker_state metric.
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerHalfOpen
+	breakerOpen
+)
+
+// tripReasons are the extraction outcomes, as labeled in
+// media_extraction_attempts_total, that count against a source: the source
+// or the bot's access to it is failing. Outcomes about the link itself (a
+// private or deleted post) show the source working.
+var tripReasons = map[string]bool{
+	metrics.ReasonTimeout:         true,
+	metrics.ReasonOther:           true,
+	metrics.ReasonBlocked:         true,
+	metrics.ReasonRateLimited:     true,
+	metrics.ReasonUpstreamChanged: true,
+}
+
+// breakers holds a circuit breaker per source, created on first use.
+type breakers struct {
+	cfg config.Breaker
+	now func() time.Time
+
+	mu sync.Mutex
+	m  map[models.MediaSource]*breaker
+}
+
+func newBreakers(cfg config.Breaker) *breakers {
+	return &breakers{cfg: cfg, now: time.Now, m: make(map[models.MediaSource]*breaker)}
+}
+
+func (bs *breakers) get(source models.MediaSource) *breaker {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	b, ok := bs.m[source]
+	if !ok {
+		b = &breaker{source: source, cfg: bs.cfg, now: bs.now}
+		bs.m[source] = b
+		metrics.SetCircuitBreakerState(string(source), int(breakerClosed))
+	}
+	return b
+}
+
+// breaker stops extractions of a source after cfg.Failures requests in a row
+// failed. While open, requests fail right away; after cfg.OpenTimeout a single
+// trial request is let through (half-open), and its outcome closes the
+// breaker or opens it again.
+type breaker struct {
+	source models.MediaSource
+	cfg    config.Breaker
+	now    func() time.Time
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	retryAt  time.Time
+	// generation changes with the state, so that requests let through in an
+	// earlier state don't count in the current one.
+	generation int
+	trial      bool
+}
+
+// allow lets a request through or returns a *CircuitOpenError. done must be
+// called with the outcome of a request let through.
+func (b *breaker) allow() (done func(error), err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerOpen && !b.now().Before(b.retryAt) {
+		b.setState(breakerHalfOpen)
+	}
+	switch b.state {
+	case breakerOpen:
+		return nil, b.openError()
+	case breakerHalfOpen:
+		if b.trial {
+			return nil, b.openError()
+		}
+		b.trial = true
+	}
+
+	generation := b.generation
+	return func(err error) { b.record(generation, err) }, nil
+}
+
+func (b *breaker) record(generation int, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation != b.generation {
+		return
+	}
+	reason := metrics.ExtractionReason(err)
+	if reason == metrics.ReasonCanceled {
+		// Nobody waits for the result anymore; it says nothing about the
+		// source.
+		if b.state == breakerHalfOpen {
+			b.trial = false
+		}
+		return
+	}
+
+	if !tripReasons[reason] {
+		b.failures = 0
+		if b.state == breakerHalfOpen {
+			b.setState(breakerClosed)
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.cfg.Failures {
+		b.retryAt = b.now().Add(b.cfg.OpenTimeout)
+		b.setState(breakerOpen)
+	}
+}
+
+func (b *breaker) setState(state breakerState) {
+	b.state = state
+	b.generation++
+	b.trial = false
+	if state == breakerClosed {
+		b.failures = 0
+	}
+	metrics.SetCircuitBreakerState(string(b.source), int(state))
+}
+
+func (b *breaker) openError() error {
+	return &CircuitOpenError{Source: b.source, RetryAt: b.retryAt}
+}
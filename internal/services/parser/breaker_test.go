@@ -0,0 +1,155 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sxwebdev/downloaderbot/internal/config"
+	"github.com/sxwebdev/downloaderbot/internal/models"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
+	"github.com/tkcrm/mx/logger"
+)
+
+// newTestBreaker returns a breaker opening after 2 failures for a minute, and
+// a function moving its clock.
+func newTestBreaker() (*breaker, func(time.Duration)) {
+	now := time.Unix(1_700_000_000, 0)
+	bs := newBreakers(config.Breaker{Failures: 2, OpenTimeout: time.Minute})
+	bs.now = func() time.Time { return now }
+	return bs.get("tiktok"), func(d time.Duration) { now = now.Add(d) }
+}
+
+func attempt(t *testing.T, b *breaker, err error) {
+	t.Helper()
+	done, openErr := b.allow()
+	if openErr != nil {
+		t.Fatalf("request not let through: %v", openErr)
+	}
+	done(err)
+}
+
+func TestBreaker_OpensAndRecovers(t *testing.T) {
+	b, advance := newTestBreaker()
+
+	attempt(t, b, extractor.ErrBlocked)
+	// Errors about the link itself show the source working.
+	attempt(t, b, extractor.ErrPrivate)
+	attempt(t, b, extractor.ErrBlocked)
+	if b.state != breakerClosed {
+		t.Fatal("opened without failures in a row")
+	}
+
+	attempt(t, b, context.DeadlineExceeded)
+	_, err := b.allow()
+	var openErr *CircuitOpenError
+	if !errors.As(err, &openErr) || !errors.Is(err, ErrSourceUnavailable) || openErr.Source != "tiktok" {
+		t.Fatalf("want a CircuitOpenError, got %v", err)
+	}
+
+	advance(time.Minute)
+	done, err := b.allow()
+	if err != nil {
+		t.Fatalf("trial request not let through: %v", err)
+	}
+	if _, err := b.allow(); err == nil {
+		t.Fatal("a second request let through while half-open")
+	}
+	done(nil)
+	if b.state != breakerClosed {
+		t.Fatalf("want closed after a successful trial, got %d", b.state)
+	}
+	attempt(t, b, nil)
+}
+
+func TestBreaker_FailedTrialReopens(t *testing.T) {
+	b, advance := newTestBreaker()
+	attempt(t, b, extractor.ErrRateLimited)
+	attempt(t, b, extractor.ErrRateLimited)
+
+	advance(time.Minute)
+	attempt(t, b, extractor.ErrUpstreamChanged)
+	if _, err := b.allow(); err == nil {
+		t.Fatal("want open after a failed trial")
+	}
+
+	advance(time.Minute)
+	done, err := b.allow()
+	if err != nil {
+		t.Fatalf("trial request not let through: %v", err)
+	}
+	// A canceled trial gives the slot to the next request.
+	done(context.Canceled)
+	attempt(t, b, nil)
+	if b.state != breakerClosed {
+		t.Fatalf("want closed, got %d", b.state)
+	}
+}
+
+func TestBreaker_StaleOutcomesIgnored(t *testing.T) {
+	b, advance := newTestBreaker()
+
+	// A request let through while closed finishes after the breaker opened
+	// and half-opened: its success must not close the breaker.
+	slow, err := b.allow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	attempt(t, b, extractor.ErrBlocked)
+	attempt(t, b, extractor.ErrBlocked)
+	advance(time.Minute)
+	trial, err := b.allow()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	slow(nil)
+	if b.state != breakerHalfOpen {
+		t.Fatalf("stale outcome changed the state to %d", b.state)
+	}
+	trial(nil)
+	if b.state != breakerClosed {
+		t.Fatalf("want closed, got %d", b.state)
+	}
+}
+
+func TestService_CatchAllSkipsBreaker(t *testing.T) {
+	ext := &canaryExtractor{name: "generic"}
+	ext.broken.Store(true)
+	s := &Service{
+		logger:   logger.Default(),
+		inflight: &flightGroup{},
+		breakers: newBreakers(config.Breaker{Failures: 2, OpenTimeout: time.Minute}),
+	}
+	linkInfo := func(host string, catchAll bool) GetLinkInfoResponse {
+		link := "https://" + host + "/page"
+		return GetLinkInfoResponse{
+			FinalURL:     link,
+			CanonicalURL: link,
+			MediaSource:  models.MediaSource(ext.name),
+			Extractors:   []extractor.Extractor{ext},
+			CatchAll:     catchAll,
+		}
+	}
+
+	// A site failing twice doesn't keep the links of another site from the
+	// catch-all chain.
+	for _, host := range []string{"down.example", "down.example", "up.example"} {
+		_, err := s.GetMedia(t.Context(), linkInfo(host, true))
+		if !errors.Is(err, extractor.ErrUpstreamChanged) {
+			t.Fatalf("%s: want the extraction error, got %v", host, err)
+		}
+	}
+	if n := ext.calls.Load(); n != 3 {
+		t.Fatalf("extracted %d time(s), want 3", n)
+	}
+
+	// The chain of a known source still opens its breaker.
+	for range 2 {
+		_, _ = s.GetMedia(t.Context(), linkInfo("generic.test", false))
+	}
+	if _, err := s.GetMedia(t.Context(), linkInfo("generic.test", false)); !errors.Is(err, ErrSourceUnavailable) {
+		t.Fatalf("want the breaker open, got %v", err)
+	}
+}
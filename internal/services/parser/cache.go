@@ -0,0 +1,33 @@
+package parser
+
+import (
+	"context"
+	"time"
+
+	"github.com/sxwebdev/downloaderbot/internal/cache"
+	"github.com/sxwebdev/downloaderbot/internal/metrics"
+	"github.com/sxwebdev/downloaderbot/internal/models"
+)
+
+// cachedMedia looks linkInfo up in the result cache. A failing store counts as
+// a miss: the media is extracted again.
+func (s *Service) cachedMedia(ctx context.Context, linkInfo GetLinkInfoResponse) (*models.Media, bool) {
+	media, ok, err := s.cache.Get(ctx, linkInfo.CanonicalURL)
+	if err != nil {
+		s.logger.Warnf("failed to read %s from cache: %s", linkInfo.CanonicalURL, err)
+		ok = false
+	}
+	metrics.ObserveCacheLookup(string(linkInfo.MediaSource), ok)
+	return media, ok
+}
+
+// cacheMedia stores media for as long as its URLs stay valid.
+func (s *Service) cacheMedia(ctx context.Context, linkInfo GetLinkInfoResponse, media *models.Media) {
+	ttl := cache.TTL(media, s.config.Cache.TTL, time.Now())
+	if ttl <= 0 {
+		return
+	}
+	if err := s.cache.Set(ctx, linkInfo.CanonicalURL, media, ttl); err != nil {
+		s.logger.Warnf("failed to cache %s: %s", linkInfo.CanonicalURL, err)
+	}
+}
@@ -0,0 +1,216 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sxwebdev/downloaderbot/internal/config"
+	"github.com/sxwebdev/downloaderbot/internal/metrics"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
+	"github.com/tkcrm/mx/logger"
+)
+
+// ErrSourceUnavailable is returned for links whose source is known to be
+// failing, so the request would most likely fail too: its extractors are all
+// degraded by their canary checks, or its circuit breaker is open (see
+// CircuitOpenError).
+var ErrSourceUnavailable = errors.New("source temporarily unavailable")
+
+// canary extracts links known to work with their extractors every interval,
+// so that an extractor broken by a change of its site is noticed before users
+// report it. An extractor failing threshold rounds in a row is degraded until
+// it passes a round again.
+type canary struct {
+	logger   logger.Logger
+	registry *extractor.Registry
+	cfg      config.Canary
+
+	mu     sync.RWMutex
+	states map[string]*sourceHealth
+}
+
+type sourceHealth struct {
+	failures int
+	degraded bool
+}
+
+// newCanary checks the configuration against the registry, where every
+// extractor with canary links must be registered.
+func newCanary(l logger.Logger, registry *extractor.Registry, cfg config.Canary) (*canary, error) {
+	c := &canary{
+		logger:   l,
+		registry: registry,
+		cfg:      cfg,
+		states:   make(map[string]*sourceHealth, len(cfg.URLs)),
+	}
+	for name, links := range cfg.URLs {
+		if _, ok := registry.GetByName(name); !ok {
+			return nil, fmt.Errorf("unknown extractor %s", name)
+		}
+		if len(links) == 0 {
+			return nil, fmt.Errorf("extractor %s has no canary links", name)
+		}
+		c.states[name] = &sourceHealth{}
+		metrics.SetSourceHealth(name, 0, false)
+	}
+	return c, nil
+}
+
+// run checks the sources right away and then every interval until ctx ends.
+func (c *canary) run(ctx context.Context) {
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		c.checkAll(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkAll runs a round: the sources are checked one after another, so that
+// the canaries never add more than one extraction to the load of the bot.
+func (c *canary) checkAll(ctx context.Context) {
+	for _, name := range slices.Sorted(maps.Keys(c.states)) {
+		err := c.check(ctx, name)
+		if ctx.Err() != nil {
+			// Shutting down; the round says nothing about the source.
+			return
+		}
+		c.record(name, err)
+	}
+}
+
+// check extracts the canary links of a source in order. The source passes as
+// soon as one of them yields media, so a single deleted post doesn't degrade
+// it; every failed link is still counted in the metrics.
+func (c *canary) check(ctx context.Context, name string) error {
+	ext, _ := c.registry.GetByName(name)
+
+	errs := make([]error, 0, len(c.cfg.URLs[name]))
+	for _, link := range c.cfg.URLs[name] {
+		err := c.extract(ctx, ext, link)
+		metrics.ObserveCanaryCheck(name, err)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", link, err))
+	}
+	return errors.Join(errs...)
+}
+
+func (c *canary) extract(ctx context.Context, ext extractor.Extractor, link string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+
+	media, err := ext.Extract(ctx, link)
+	if err != nil {
+		return err
+	}
+	if len(media.Items) == 0 {
+		return errors.New("no media items")
+	}
+	return nil
+}
+
+// record updates the health of a source with the result of its round.
+func (c *canary) record(name string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	st := c.states[name]
+	switch {
+	case err == nil:
+		if st.degraded {
+			c.logger.Infof("source %s passes its canary checks again", name)
+		}
+		st.failures, st.degraded = 0, false
+	default:
+		st.failures++
+		c.logger.Warnf("source %s failed its canary checks %d time(s) in a row: %s", name, st.failures, err)
+		if !st.degraded && st.failures >= c.cfg.Threshold {
+			st.degraded = true
+			c.logger.Errorf("source %s is degraded", name)
+		}
+	}
+	metrics.SetSourceHealth(name, st.failures, st.degraded)
+}
+
+// degraded reports whether the extractor called name is degraded. Extractors
+// without canary links never are.
+func (c *canary) degraded(name string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	st, ok := c.states[name]
+	return ok && st.degraded
+}
+
+// err lists the degraded sources when all of them are, nil otherwise. A
+// single site changing leaves the bot serving the others, so it is reported
+// by the source_healthy metric rather than by the readiness probe.
+func (c *canary) err() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	names := make([]string, 0, len(c.states))
+	for name, st := range c.states {
+		if !st.degraded {
+			return nil
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	slices.Sort(names)
+	return fmt.Errorf("degraded sources: %s", strings.Join(names, ", "))
+}
+
+// canaryStatus is the state of the checked sources served on the status
+// address.
+type canaryStatus struct {
+	Degraded []string                `json:"degraded"`
+	Sources  map[string]sourceStatus `json:"sources"`
+}
+
+type sourceStatus struct {
+	Degraded            bool `json:"degraded"`
+	ConsecutiveFailures int  `json:"consecutive_failures"`
+}
+
+// status returns the state of every checked source, degraded names sorted.
+func (c *canary) status() canaryStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	st := canaryStatus{
+		Degraded: []string{},
+		Sources:  make(map[string]sourceStatus, len(c.states)),
+	}
+	for name, h := range c.states {
+		st.Sources[name] = sourceStatus{Degraded: h.degraded, ConsecutiveFailures: h.failures}
+		if h.degraded {
+			st.Degraded = append(st.Degraded, name)
+		}
+	}
+	slices.Sort(st.Degraded)
+	return st
+}
+
+// ServeHTTP answers with the status as JSON. It always succeeds: whether the
+// bot is ready is for the readiness probe to tell.
+func (c *canary) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(c.status())
+}
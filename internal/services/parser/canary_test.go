@@ -0,0 +1,178 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sxwebdev/downloaderbot/internal/config"
+	"github.com/sxwebdev/downloaderbot/internal/models"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
+	"github.com/tkcrm/mx/logger"
+)
+
+// canaryExtractor fails every link while broken is set.
+type canaryExtractor struct {
+	name   string
+	broken atomic.Bool
+	calls  atomic.Int32
+}
+
+func (e *canaryExtractor) Name() string    { return e.name }
+func (e *canaryExtractor) Hosts() []string { return []string{e.name + ".test"} }
+
+func (e *canaryExtractor) Extract(ctx context.Context, url string) (*models.Media, error) {
+	e.calls.Add(1)
+	if e.broken.Load() {
+		return nil, extractor.ErrUpstreamChanged
+	}
+	return &models.Media{Items: []*models.MediaItem{{Type: models.MediaTypeVideo, Url: "https://cdn.test/v.mp4"}}}, nil
+}
+
+func newTestCanary(t *testing.T, exts ...*canaryExtractor) *canary {
+	t.Helper()
+	r := extractor.NewRegistry()
+	urls := make(map[string][]string)
+	for _, ext := range exts {
+		if err := r.Register(ext); err != nil {
+			t.Fatalf("Register: %v", err)
+		}
+		urls[ext.name] = []string{"https://" + ext.name + ".test/1", "https://" + ext.name + ".test/2"}
+	}
+	c, err := newCanary(logger.Default(), r, config.Canary{
+		Interval:  time.Hour,
+		Timeout:   time.Second,
+		Threshold: 2,
+		URLs:      urls,
+	})
+	if err != nil {
+		t.Fatalf("newCanary: %v", err)
+	}
+	return c
+}
+
+func TestCanary_DegradesAndRecovers(t *testing.T) {
+	ok := &canaryExtractor{name: "ok"}
+	flaky := &canaryExtractor{name: "flaky"}
+	c := newTestCanary(t, ok, flaky)
+	ctx := t.Context()
+
+	flaky.broken.Store(true)
+	c.checkAll(ctx)
+	if c.degraded("flaky") || c.err() != nil {
+		t.Fatal("degraded after a single failed round")
+	}
+	// Every link of a failing source is tried, a passing one stops at the first.
+	if flaky.calls.Load() != 2 || ok.calls.Load() != 1 {
+		t.Fatalf("unexpected calls: flaky %d, ok %d", flaky.calls.Load(), ok.calls.Load())
+	}
+
+	c.checkAll(ctx)
+	if !c.degraded("flaky") || c.degraded("ok") {
+		t.Fatal("want flaky degraded after the threshold")
+	}
+	// The other source still works, so the bot stays ready.
+	if err := c.err(); err != nil {
+		t.Fatalf("unexpected readiness error %v", err)
+	}
+
+	ok.broken.Store(true)
+	c.checkAll(ctx)
+	c.checkAll(ctx)
+	if err := c.err(); err == nil || err.Error() != "degraded sources: flaky, ok" {
+		t.Fatalf("unexpected readiness error %v", err)
+	}
+	ok.broken.Store(false)
+
+	flaky.broken.Store(false)
+	c.checkAll(ctx)
+	if c.degraded("flaky") || c.err() != nil {
+		t.Fatal("want flaky healthy after a passing round")
+	}
+}
+
+func TestCanary_Status(t *testing.T) {
+	ok := &canaryExtractor{name: "ok"}
+	flaky := &canaryExtractor{name: "flaky"}
+	c := newTestCanary(t, ok, flaky)
+
+	flaky.broken.Store(true)
+	c.checkAll(t.Context())
+	c.checkAll(t.Context())
+
+	// The bot is still ready, yet the status names the degraded source.
+	if err := c.err(); err != nil {
+		t.Fatalf("unexpected readiness error %v", err)
+	}
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status code %d", rec.Code)
+	}
+	var got canaryStatus
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := canaryStatus{
+		Degraded: []string{"flaky"},
+		Sources: map[string]sourceStatus{
+			"flaky": {Degraded: true, ConsecutiveFailures: 2},
+			"ok":    {},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("status = %+v, want %+v", got, want)
+	}
+}
+
+func TestCanary_CanceledRoundIsNotRecorded(t *testing.T) {
+	ext := &canaryExtractor{name: "x"}
+	ext.broken.Store(true)
+	c := newTestCanary(t, ext)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	for range 3 {
+		c.checkAll(ctx)
+	}
+	if c.degraded("x") {
+		t.Fatal("rounds cut short by shutdown degraded the source")
+	}
+}
+
+func TestCanary_UnknownExtractor(t *testing.T) {
+	_, err := newCanary(logger.Default(), extractor.NewRegistry(), config.Canary{URLs: map[string][]string{"nope": {"https://nope.test"}}})
+	if err == nil {
+		t.Fatal("want an error for an unregistered extractor")
+	}
+}
+
+func TestService_Unavailable(t *testing.T) {
+	primary := &canaryExtractor{name: "primary"}
+	fallback := &canaryExtractor{name: "fallback"}
+	c := newTestCanary(t, primary, fallback)
+	s := &Service{canary: c}
+	chain := []extractor.Extractor{primary, fallback}
+
+	primary.broken.Store(true)
+	c.checkAll(t.Context())
+	c.checkAll(t.Context())
+	if s.unavailable(chain) {
+		t.Fatal("unavailable while the fallback is healthy")
+	}
+
+	fallback.broken.Store(true)
+	c.checkAll(t.Context())
+	c.checkAll(t.Context())
+	if !s.unavailable(chain) {
+		t.Fatal("want unavailable when the whole chain is degraded")
+	}
+	if s.Healthy(t.Context()) == nil {
+		t.Fatal("want the service unhealthy")
+	}
+}
@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sxwebdev/downloaderbot/internal/models"
+)
+
+// flightGroup coalesces concurrent extractions of the same link: the first
+// caller starts the extraction and everyone asking for the key before it
+// finishes waits for its result instead of starting another one.
+//
+// The extraction doesn't run under any single caller's context. A caller
+// whose context ends stops waiting without affecting the others; only when
+// the last waiter has gone is the extraction canceled.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done    chan struct{}
+	media   *models.Media
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// do returns the result of fn for key, calling fn only if no call for key is
+// in flight. shared reports whether the result came from another caller's
+// call. Every caller gets its own copy of the media.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(context.Context) (*models.Media, error)) (media *models.Media, shared bool, err error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	c, shared := g.calls[key]
+	if !shared {
+		// Keep the values of ctx (logger fields, trace spans) but not its
+		// cancellation: it belongs to this caller only.
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		c = &flightCall{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = c
+		go g.run(callCtx, key, c, fn)
+	}
+	c.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		if c.err != nil {
+			return nil, shared, c.err
+		}
+		return c.media.Clone(), shared, nil
+	case <-ctx.Done():
+		g.leave(key, c)
+		return nil, shared, ctx.Err()
+	}
+}
+
+func (g *flightGroup) run(ctx context.Context, key string, c *flightCall, fn func(context.Context) (*models.Media, error)) {
+	c.media, c.err = fn(ctx)
+	c.cancel()
+
+	g.mu.Lock()
+	if g.calls[key] == c {
+		delete(g.calls, key)
+	}
+	g.mu.Unlock()
+
+	close(c.done)
+}
+
+// leave drops a waiter whose context ended. The last one to leave cancels the
+// call and forgets it, so that a later caller starts afresh instead of getting
+// the cancellation error.
+func (g *flightGroup) leave(key string, c *flightCall) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	c.waiters--
+	if c.waiters > 0 {
+		return
+	}
+	c.cancel()
+	if g.calls[key] == c {
+		delete(g.calls, key)
+	}
+}
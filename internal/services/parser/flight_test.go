@@ -0,0 +1,160 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sxwebdev/downloaderbot/internal/models"
+)
+
+func TestFlightGroup_Shared(t *testing.T) {
+	var g flightGroup
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fn := func(context.Context) (*models.Media, error) {
+		calls.Add(1)
+		<-release
+		return &models.Media{Items: []*models.MediaItem{{Url: "https://cdn.test/a.mp4"}}}, nil
+	}
+
+	const callers = 10
+	results := make([]*models.Media, callers)
+	var wg sync.WaitGroup
+	for i := range callers {
+		wg.Go(func() {
+			media, _, err := g.do(context.Background(), "key", fn)
+			if err != nil {
+				t.Errorf("caller %d: %v", i, err)
+			}
+			results[i] = media
+		})
+	}
+	waitWaiters(t, &g, "key", callers)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("fn called %d times, want 1", n)
+	}
+	// Each caller owns its copy.
+	results[0].Items[0].Url = "changed"
+	if results[1].Items[0].Url != "https://cdn.test/a.mp4" {
+		t.Fatal("callers share the media")
+	}
+}
+
+func TestFlightGroup_SharedError(t *testing.T) {
+	var g flightGroup
+	wantErr := errors.New("boom")
+	release := make(chan struct{})
+	fn := func(context.Context) (*models.Media, error) {
+		<-release
+		return nil, wantErr
+	}
+
+	errs := make(chan error, 2)
+	for range 2 {
+		go func() {
+			_, _, err := g.do(context.Background(), "key", fn)
+			errs <- err
+		}()
+	}
+	waitWaiters(t, &g, "key", 2)
+	close(release)
+
+	for range 2 {
+		if err := <-errs; !errors.Is(err, wantErr) {
+			t.Fatalf("want %v, got %v", wantErr, err)
+		}
+	}
+}
+
+func TestFlightGroup_Cancellation(t *testing.T) {
+	t.Run("one waiter leaves", func(t *testing.T) {
+		var g flightGroup
+		release := make(chan struct{})
+		fnCtx := make(chan context.Context, 1)
+		fn := func(ctx context.Context) (*models.Media, error) {
+			fnCtx <- ctx
+			<-release
+			return &models.Media{Title: "ok"}, ctx.Err()
+		}
+
+		leaving, leave := context.WithCancel(context.Background())
+		leftErr := make(chan error, 1)
+		go func() {
+			_, _, err := g.do(leaving, "key", fn)
+			leftErr <- err
+		}()
+		stayed := make(chan *models.Media, 1)
+		go func() {
+			media, _, _ := g.do(context.Background(), "key", fn)
+			stayed <- media
+		}()
+		waitWaiters(t, &g, "key", 2)
+
+		leave()
+		if err := <-leftErr; !errors.Is(err, context.Canceled) {
+			t.Fatalf("leaving waiter: want context.Canceled, got %v", err)
+		}
+		if err := (<-fnCtx).Err(); err != nil {
+			t.Fatalf("extraction canceled while a waiter remains: %v", err)
+		}
+
+		close(release)
+		if media := <-stayed; media == nil || media.Title != "ok" {
+			t.Fatalf("remaining waiter got %+v", media)
+		}
+	})
+
+	t.Run("last waiter leaves", func(t *testing.T) {
+		var g flightGroup
+		fnCtx := make(chan context.Context, 1)
+		fn := func(ctx context.Context) (*models.Media, error) {
+			fnCtx <- ctx
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if _, _, err := g.do(ctx, "key", fn); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("want context.DeadlineExceeded, got %v", err)
+		}
+
+		select {
+		case <-(<-fnCtx).Done():
+		case <-time.After(time.Second):
+			t.Fatal("extraction not canceled after its last waiter left")
+		}
+
+		// A new caller starts afresh instead of joining the canceled call.
+		media, shared, err := g.do(context.Background(), "key", func(context.Context) (*models.Media, error) {
+			return &models.Media{Title: "fresh"}, nil
+		})
+		if err != nil || shared || media.Title != "fresh" {
+			t.Fatalf("got %+v, shared %v, err %v", media, shared, err)
+		}
+	})
+}
+
+// waitWaiters blocks until n callers wait for key.
+func waitWaiters(t *testing.T, g *flightGroup, key string, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		g.mu.Lock()
+		c := g.calls[key]
+		waiting := c != nil && c.waiters == n
+		g.mu.Unlock()
+		if waiting {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d waiters of %q", n, key)
+}
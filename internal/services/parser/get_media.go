@@ -2,6 +2,7 @@ package parser
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"time"
@@ -11,16 +12,33 @@ import (
 	"github.com/sxwebdev/downloaderbot/internal/util"
 	"github.com/sxwebdev/downloaderbot/pkg/extractor"
 	// Import extractor packages to register them
+	_ "github.com/sxwebdev/downloaderbot/pkg/extractor/direct"
+	_ "github.com/sxwebdev/downloaderbot/pkg/extractor/generic"
 	_ "github.com/sxwebdev/downloaderbot/pkg/extractor/instagram"
 	_ "github.com/sxwebdev/downloaderbot/pkg/extractor/lux"
+	_ "github.com/sxwebdev/downloaderbot/pkg/extractor/tiktok"
 	_ "github.com/sxwebdev/downloaderbot/pkg/extractor/youtube"
 )
 
 type GetLinkInfoResponse struct {
 	RequestLink string
-	MediaSource models.MediaSource
-	Url         *url.URL
-	Extractor   extractor.Extractor
+	// FinalURL is RequestLink with short links and redirects resolved; it is
+	// what the extractors get. Url is its parsed form.
+	FinalURL string
+	// CanonicalURL is the primary extractor's canonical form of FinalURL (see
+	// extractor.Canonicalizer). It identifies the media in the result cache,
+	// among in-flight extractions and in logs.
+	CanonicalURL string
+	MediaSource  models.MediaSource
+	Url          *url.URL
+	// Extractor is the primary extractor for the link and Extractors the whole
+	// priority-ordered fallback chain, starting with Extractor.
+	Extractor    extractor.Extractor
+	Extractors   []extractor.Extractor
+	Capabilities extractor.Capabilities
+	// CatchAll is set when no extractor registers the host, so Extractors is
+	// the catch-all chain.
+	CatchAll bool
 }
 
 func (s *Service) GetLinkInfo(ctx context.Context, link string) (GetLinkInfoResponse, error) {
@@ -32,39 +50,120 @@ func (s *Service) GetLinkInfo(ctx context.Context, link string) (GetLinkInfoResp
 		return GetLinkInfoResponse{}, fmt.Errorf("received invalid link")
 	}
 
+	// Expand short links (t.co, bit.ly, ...) until a known host is reached
+	finalURL, err := s.resolver.Resolve(ctx, link)
+	if err != nil {
+		return GetLinkInfoResponse{}, fmt.Errorf("failed to resolve link: %w", err)
+	}
+
 	// Convert link to URL object
-	uri, err := url.ParseRequestURI(link)
+	uri, err := url.ParseRequestURI(finalURL)
 	if err != nil {
-		return GetLinkInfoResponse{}, fmt.Errorf("failed to parse link %s: %w", link, err)
+		return GetLinkInfoResponse{}, fmt.Errorf("failed to parse link %s: %w", finalURL, err)
 	}
 
 	// Get extractor from registry
 	registry := extractor.GetRegistry()
-	ext, err := registry.GetByURL(link)
+	chain, err := registry.GetChainByURL(finalURL)
+	if errors.Is(err, extractor.ErrUnsupportedURL) {
+		// The source is known, only this link shape isn't; say so as it is.
+		return GetLinkInfoResponse{}, err
+	}
 	if err != nil {
 		return GetLinkInfoResponse{}, fmt.Errorf("unsupported source: %w", err)
 	}
+	ext := chain[0]
+
+	// Answer right away instead of running extractors that fail their
+	// canary checks.
+	if s.unavailable(chain) {
+		return GetLinkInfoResponse{}, fmt.Errorf("%s: %w", ext.Name(), ErrSourceUnavailable)
+	}
+
+	// ext came from this registry, so its capabilities are always recorded.
+	caps, _ := registry.GetCapabilities(ext.Name())
+
+	canonicalURL, err := extractor.Canonicalize(ext, finalURL)
+	if err != nil {
+		// The link passed the extractor's patterns, so this is a mismatch
+		// between them and its canonical forms; fall back to the plain link.
+		s.logger.Warnf("failed to canonicalize %s: %s", finalURL, err)
+		canonicalURL = finalURL
+	}
 
 	return GetLinkInfoResponse{
-		RequestLink: link,
-		MediaSource: models.MediaSource(ext.Name()),
-		Url:         uri,
-		Extractor:   ext,
+		RequestLink:  link,
+		FinalURL:     finalURL,
+		CanonicalURL: canonicalURL,
+		MediaSource:  models.MediaSource(ext.Name()),
+		Url:          uri,
+		Extractor:    ext,
+		Extractors:   chain,
+		Capabilities: caps,
+		CatchAll:     len(registry.GetChainByHost(uri.Host)) == 0,
 	}, nil
 }
 
+// GetMedia returns the media behind linkInfo, from the result cache when the
+// link was extracted recently and its media URLs are still valid. Concurrent
+// requests for the same link share a single extraction.
 func (s *Service) GetMedia(ctx context.Context, linkInfo GetLinkInfoResponse) (*models.Media, error) {
-	if linkInfo.Extractor == nil {
+	if s.cache != nil {
+		if media, ok := s.cachedMedia(ctx, linkInfo); ok {
+			return media, nil
+		}
+	}
+
+	media, shared, err := s.inflight.do(ctx, linkInfo.CanonicalURL, func(ctx context.Context) (media *models.Media, err error) {
+		// The catch-all chain serves every unknown host, and one of them
+		// failing says nothing about the others.
+		if s.breakers != nil && !linkInfo.CatchAll {
+			done, openErr := s.breakers.get(linkInfo.MediaSource).allow()
+			if openErr != nil {
+				return nil, openErr
+			}
+			// The outcome of the whole chain counts, so that a fallback
+			// serving the link keeps the source closed.
+			defer func() { done(err) }()
+		}
+
+		media, err = s.extract(ctx, linkInfo)
+		if err == nil && s.cache != nil {
+			s.cacheMedia(ctx, linkInfo, media)
+		}
+		return media, err
+	})
+	if shared {
+		s.logger.Debugf("joined an in-flight extraction of %s", linkInfo.CanonicalURL)
+	}
+
+	return media, err
+}
+
+// extract runs the extractors of the host's chain in order: one that reports
+// the link as not supported or blocked hands it over to the next, any other
+// error ends the chain.
+func (s *Service) extract(ctx context.Context, linkInfo GetLinkInfoResponse) (*models.Media, error) {
+	if len(linkInfo.Extractors) == 0 {
 		return nil, fmt.Errorf("no extractor available for this source")
 	}
 
 	source := string(linkInfo.MediaSource)
-	start := time.Now()
-	media, err := linkInfo.Extractor.Extract(ctx, linkInfo.RequestLink)
-	metrics.ObserveExtractionAttempt(source, start, err)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get media from source: %w", err)
+	errs := make([]error, 0, len(linkInfo.Extractors))
+	for _, ext := range linkInfo.Extractors {
+		start := time.Now()
+		media, err := ext.Extract(ctx, linkInfo.FinalURL)
+		metrics.ObserveExtractionAttempt(source, ext.Name(), start, err)
+		if err == nil {
+			return media, nil
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", ext.Name(), err))
+		if !extractor.ShouldFallThrough(err) {
+			break
+		}
+		s.logger.Infof("extractor %s gave up on %s, trying the next one: %s", ext.Name(), linkInfo.CanonicalURL, err)
 	}
 
-	return media, nil
+	return nil, fmt.Errorf("failed to get media from source: %w", errors.Join(errs...))
 }
@@ -2,32 +2,262 @@ package parser
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/sxwebdev/downloaderbot/internal/cache"
 	"github.com/sxwebdev/downloaderbot/internal/config"
+	"github.com/sxwebdev/downloaderbot/internal/metrics"
+	"github.com/sxwebdev/downloaderbot/internal/resolver"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor/declarative"
+	igextractor "github.com/sxwebdev/downloaderbot/pkg/extractor/instagram"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor/lux"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor/plugin"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor/script"
+	"github.com/sxwebdev/downloaderbot/pkg/instagram"
 	"github.com/tkcrm/mx/logger"
 )
 
 const serviceName = "parser-service"
 
+// healthCheckInterval is how often the health of the service is polled; it
+// only reads the state of the canary checks.
+const healthCheckInterval = 10 * time.Second
+
 type Service struct {
-	logger logger.Logger
-	config *config.Config
-	name   string
+	logger   logger.Logger
+	config   *config.Config
+	name     string
+	resolver *resolver.Resolver
+	// cache keeps extraction results; nil when disabled.
+	cache    cache.Store
+	inflight *flightGroup
+	// canary checks the extractors; nil when disabled.
+	canary *canary
+	// breakers fail requests to failing sources fast; nil when disabled.
+	breakers *breakers
+}
+
+func New(l logger.Logger, cfg *config.Config) (*Service, error) {
+	if err := configureLux(cfg.Lux); err != nil {
+		return nil, fmt.Errorf("failed to configure lux: %w", err)
+	}
+	if err := configureInstagram(cfg.Instagram); err != nil {
+		return nil, fmt.Errorf("failed to configure instagram: %w", err)
+	}
+	if err := registerPlugins(cfg.Plugins); err != nil {
+		return nil, fmt.Errorf("failed to register plugins: %w", err)
+	}
+	if err := registerExtractors(cfg.Extractors); err != nil {
+		return nil, fmt.Errorf("failed to register declarative extractors: %w", err)
+	}
+	if err := registerScripts(cfg.Scripts); err != nil {
+		return nil, fmt.Errorf("failed to register scripts: %w", err)
+	}
+
+	s := &Service{
+		logger:   logger.With(l, "service", serviceName),
+		config:   cfg,
+		name:     serviceName,
+		resolver: resolver.New(isKnownHost),
+		inflight: &flightGroup{},
+	}
+	if cfg.Cache.Enabled {
+		s.cache = cache.NewLRU(cfg.Cache.Size)
+	}
+	if cfg.Breaker.Enabled {
+		s.breakers = newBreakers(cfg.Breaker)
+	}
+	if cfg.Canary.Enabled {
+		c, err := newCanary(s.logger, extractor.GetRegistry(), cfg.Canary)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure canary checks: %w", err)
+		}
+		s.canary = c
+	}
+	return s, nil
+}
+
+// configureLux passes the per-site lux options on to the lux extractors.
+func configureLux(cfg config.Lux) error {
+	sites := make(map[string]lux.SiteOptions, len(cfg.Sites))
+	for name, site := range cfg.Sites {
+		opts := lux.SiteOptions{
+			Playlist:  site.Playlist,
+			Items:     site.Items,
+			ItemStart: site.ItemStart,
+			ItemEnd:   site.ItemEnd,
+			Timeout:   site.Timeout,
+		}
+		if site.CookieFile != "" {
+			cookie, err := os.ReadFile(site.CookieFile)
+			if err != nil {
+				return fmt.Errorf("site %s: failed to read cookie file: %w", name, err)
+			}
+			opts.Cookie = strings.TrimSpace(string(cookie))
+		}
+		sites[name] = opts
+	}
+	return lux.Configure(sites)
+}
+
+// configureInstagram sets the fetch strategies of the Instagram extractor.
+func configureInstagram(cfg config.Instagram) error {
+	timeouts := map[string]time.Duration{
+		instagram.StrategyGraphQL: cfg.GraphQLTimeout,
+		instagram.StrategyEmbed:   cfg.EmbedTimeout,
+		instagram.StrategyBrowser: cfg.BrowserTimeout,
+	}
+	strategies := make([]instagram.Strategy, 0, len(cfg.Strategies))
+	for _, name := range cfg.Strategies {
+		s, err := instagram.NewStrategy(name, timeouts[name])
+		if err != nil {
+			return err
+		}
+		strategies = append(strategies, s)
+	}
+	igextractor.Configure(instagram.NewChainFetcher(strategies,
+		instagram.WithCooldown(cfg.Cooldown),
+		instagram.WithObserver(metrics.InstagramFetchObserver{}),
+	))
+	return nil
+}
+
+// registerPlugins adds the configured plugin extractors to the registry, in
+// name order so that plugins of equal priority keep a stable order.
+func registerPlugins(plugins map[string]config.Plugin) error {
+	for _, name := range slices.Sorted(maps.Keys(plugins)) {
+		p := plugins[name]
+		err := plugin.Register(extractor.GetRegistry(), plugin.Config{
+			Name:            name,
+			Hosts:           p.Hosts,
+			Command:         p.Command,
+			Timeout:         p.Timeout,
+			Priority:        p.Priority,
+			Inline:          p.Inline,
+			DownloadHeaders: p.DownloadHeaders,
+			Formats:         p.Formats,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// registerExtractors adds the configured declarative extractors to the
+// registry, in name order like the plugins.
+func registerExtractors(extractors config.Extractors) error {
+	for _, name := range slices.Sorted(maps.Keys(extractors)) {
+		if err := declarative.Register(extractor.GetRegistry(), extractors[name].Definition(name)); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func New(l logger.Logger, cfg *config.Config) *Service {
-	return &Service{
-		logger: logger.With(l, "service", serviceName),
-		config: cfg,
-		name:   serviceName,
+// registerScripts reads the configured scripts and adds them to the registry,
+// in name order like the plugins.
+func registerScripts(scripts map[string]config.Script) error {
+	for _, name := range slices.Sorted(maps.Keys(scripts)) {
+		s := scripts[name]
+		source, err := os.ReadFile(s.File)
+		if err != nil {
+			return fmt.Errorf("script %s: %w", name, err)
+		}
+		err = script.Register(extractor.GetRegistry(), script.Config{
+			Name:            name,
+			Hosts:           s.Hosts,
+			Source:          string(source),
+			Timeout:         s.Timeout,
+			MaxHostBytes:    s.MaxHostBytes,
+			Priority:        s.Priority,
+			Inline:          s.Inline,
+			DownloadHeaders: s.DownloadHeaders,
+			Formats:         s.Formats,
+		})
+		if err != nil {
+			return err
+		}
 	}
+	return nil
+}
+
+// isKnownHost reports whether some extractor handles the link's host, which
+// is where short-link resolution stops.
+func isKnownHost(u *url.URL) bool {
+	return len(extractor.GetRegistry().GetChainByHost(u.Host)) > 0
 }
 
 func (s Service) Name() string { return s.name }
 
+// Start runs the canary checks, when enabled, until ctx ends.
 func (s *Service) Start(ctx context.Context) error {
+	if s.canary != nil {
+		if addr := s.config.Canary.StatusAddr; addr != "" {
+			if err := s.serveCanaryStatus(ctx, addr); err != nil {
+				return err
+			}
+		}
+		s.canary.run(ctx)
+		return nil
+	}
 	<-ctx.Done()
 	return nil
 }
 
+// serveCanaryStatus serves the state of the checked sources on addr until ctx
+// ends.
+func (s *Service) serveCanaryStatus(ctx context.Context, addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for the canary status: %w", err)
+	}
+	srv := &http.Server{
+		Handler:           s.canary,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Errorf("canary status server: %v", err)
+		}
+	}()
+	context.AfterFunc(ctx, func() { _ = srv.Close() })
+	return nil
+}
+
+// Interval is how often the launcher asks the service whether it is healthy.
+func (s *Service) Interval() time.Duration { return healthCheckInterval }
+
+// Healthy fails the readiness probe when every source with canary checks is
+// degraded.
+func (s *Service) Healthy(ctx context.Context) error {
+	if s.canary == nil {
+		return nil
+	}
+	return s.canary.err()
+}
+
+// unavailable reports whether every extractor of chain is degraded.
+func (s *Service) unavailable(chain []extractor.Extractor) bool {
+	if s.canary == nil {
+		return false
+	}
+	for _, ext := range chain {
+		if !s.canary.degraded(ext.Name()) {
+			return false
+		}
+	}
+	return true
+}
+
 func (s Service) Stop(ctx context.Context) error { return nil }
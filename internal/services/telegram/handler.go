@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"slices"
@@ -19,15 +20,16 @@ import (
 	"github.com/sxwebdev/downloaderbot/internal/models"
 	"github.com/sxwebdev/downloaderbot/internal/services/parser"
 	"github.com/sxwebdev/downloaderbot/internal/util"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
 	"github.com/sxwebdev/xutils/retry"
-	"github.com/tkcrm/modules/pkg/utils"
 	"github.com/tkcrm/mx/logger"
 	"golang.org/x/sync/errgroup"
 	"gopkg.in/telebot.v3"
 )
 
 // maxFileSize is the Telegram Bot API limit for a file the bot uploads itself
-// via multipart/form-data — the chat path, which streams the bytes.
+// via multipart/form-data — the chat path, which streams the bytes. A local
+// Bot API server takes larger files, see config.TelegramMaxUploadSize.
 const maxFileSize = 50 * 1024 * 1024
 
 // maxURLFileSize is the Telegram Bot API limit for content Telegram fetches from
@@ -41,6 +43,7 @@ const maxURLFileSize = 20 * 1024 * 1024
 type processStats struct {
 	FetchDuration time.Duration // time spent fetching media (extraction + retries)
 	Attempts      int           // number of fetch attempts performed
+	CanonicalURL  string        // canonical form of the link, empty if it was not recognized
 }
 
 // requestKind labels where a request originated, for structured logs.
@@ -66,6 +69,9 @@ func logResult(l logger.Logger, link string, start time.Time, stats processStats
 		"fetch_duration", stats.FetchDuration.String(),
 		"attempts", stats.Attempts,
 	}
+	if stats.CanonicalURL != "" {
+		fields = append(fields, "canonical_url", stats.CanonicalURL)
+	}
 	if err != nil {
 		l.Errorw(err.Error(), fields...)
 		return
@@ -169,7 +175,7 @@ func (s *handler) OnText(tgCtx telebot.Context) error {
 		}
 
 		logResult(l, link, start, stats, err)
-		return replyError(tgCtx, err.Error())
+		return replyError(tgCtx, userMessage(err))
 	}
 
 	logResult(l, link, start, stats, nil)
@@ -205,12 +211,13 @@ func (s *handler) OnQuery(c telebot.Context) error {
 	linkInfo, err := s.parserService.GetLinkInfo(ctx, link)
 	if err != nil {
 		l.Warnf("get link info error: %s", err)
-		return answerInlineError(c, "Couldn't process this link")
+		return answerInlineError(c, userMessage(err))
 	}
 
-	// YouTube inline queries are not supported due to large file sizes
-	if linkInfo.MediaSource == models.MediaSourceYoutube {
-		return answerInlineError(c, "YouTube is not supported in inline mode")
+	// Sources that can't be offered inline (large format lists, URLs that need
+	// download headers) are rejected before any extraction work.
+	if !linkInfo.Capabilities.Inline {
+		return answerInlineError(c, fmt.Sprintf("%s links are not supported in inline mode", linkInfo.MediaSource))
 	}
 
 	observeActiveUser(c)
@@ -219,12 +226,12 @@ func (s *handler) OnQuery(c telebot.Context) error {
 	data, stats, err := s.fetchMedia(ctx, linkInfo, 3, time.Second)
 	if err != nil {
 		logResult(l, link, start, stats, err)
-		return answerInlineError(c, "Failed to fetch media, please try again")
+		return answerInlineError(c, userMessage(err))
 	}
 
 	metrics.InlineRequests.Inc()
 
-	description := truncateRunes(data.Caption, 1000)
+	description := truncateRunes(joinNonEmpty("\n", byline(data), data.Caption), 1000)
 
 	results := make(telebot.Results, 0, len(data.Items))
 	for i, item := range data.Items {
@@ -260,19 +267,24 @@ func (s *handler) inlineResultFor(ctx context.Context, item *models.MediaItem, i
 	// Inline results can only reference a publicly fetchable URL (Telegram
 	// downloads it itself). Items that require download headers (e.g. TikTok)
 	// can't be offered inline — skip them. See README "Known limitations".
-	directURL, ok := s.loader.DirectURL(item)
-	if !ok {
+	if _, ok := s.loader.DirectURL(item); !ok {
 		return nil, false
 	}
 
 	switch item.Type {
 	case models.MediaTypeVideo:
 		// Telegram fetches the URL itself and gives up above maxURLFileSize,
-		// leaving a result that silently never sends. Offer a download link
-		// instead — same fallback as the chat handler. An unknown size (the CDN
-		// answered no HEAD) is not treated as too large: offering the video is
-		// still the better guess.
-		if size, err := s.loader.ContentLength(ctx, item); err == nil && size > maxURLFileSize {
+		// leaving a result that silently never sends. Offer the best variant
+		// within the limit, and a download link only when none fits — same
+		// fallback as the chat handler. An unknown size (the CDN answered
+		// without one) is not treated as too large: offering the video is still
+		// the better guess.
+		item, fits := media.Select(ctx, s.loader, item, maxURLFileSize)
+		directURL, ok := s.loader.DirectURL(item)
+		if !ok {
+			return nil, false
+		}
+		if !fits {
 			return tooLargeResult(directURL, maxURLFileSize), true
 		}
 		return &telebot.VideoResult{
@@ -288,6 +300,10 @@ func (s *handler) inlineResultFor(ctx context.Context, item *models.MediaItem, i
 			Duration: item.Duration,
 		}, true
 	case models.MediaTypePhoto:
+		directURL, ok := s.loader.DirectURL(media.Candidates(item)[0])
+		if !ok {
+			return nil, false
+		}
 		return &telebot.PhotoResult{
 			URL:      directURL,
 			ThumbURL: directURL, // required for photos
@@ -317,12 +333,12 @@ func (s *handler) processLink(tgCtx telebot.Context, link string) (processStats,
 		return stats, err
 	}
 
-	// YouTube has special handling with quality options
-	if data.Source == models.MediaSourceYoutube {
-		return stats, s.processYoutube(tgCtx, data)
+	// Format lists (e.g. YouTube qualities) are offered as download links
+	if linkInfo.Capabilities.Output == extractor.OutputFormats {
+		return stats, s.processFormats(tgCtx, data)
 	}
 
-	// All other sources use the generic media handler (like Instagram)
+	// Ready-to-send items use the generic media handler (like Instagram)
 	return stats, s.processGenericMedia(ctx, tgCtx, data)
 }
 
@@ -331,9 +347,13 @@ func (s *handler) processLink(tgCtx telebot.Context, link string) (processStats,
 // inline handlers, which differ only in how aggressively they may retry within
 // their respective timeouts.
 func (s *handler) fetchMedia(ctx context.Context, linkInfo parser.GetLinkInfoResponse, maxAttempts int, delay time.Duration) (*models.Media, processStats, error) {
-	var stats processStats
+	stats := processStats{CanonicalURL: linkInfo.CanonicalURL}
 	var data *models.Media
 
+	// final ends the retries: a source known to be failing, or an error that
+	// would come back the same on every attempt, is reported right away.
+	var final error
+
 	fetchStart := time.Now()
 	err := retry.New(
 		retry.WithContext(ctx),
@@ -345,6 +365,10 @@ func (s *handler) fetchMedia(ctx context.Context, linkInfo parser.GetLinkInfoRes
 
 		var err error
 		data, err = s.parserService.GetMedia(ctx, linkInfo)
+		if errors.Is(err, parser.ErrSourceUnavailable) || (err != nil && !retryable(err)) {
+			final = err
+			return nil
+		}
 		if err != nil {
 			return err
 		}
@@ -361,6 +385,9 @@ func (s *handler) fetchMedia(ctx context.Context, linkInfo parser.GetLinkInfoRes
 		return nil
 	})
 	stats.FetchDuration = time.Since(fetchStart)
+	if final != nil {
+		err = final
+	}
 	metrics.ObserveExtractionRequest(string(linkInfo.MediaSource), err)
 	if err != nil {
 		return nil, stats, fmt.Errorf("failed to get media: %w", err)
@@ -369,10 +396,49 @@ func (s *handler) fetchMedia(ctx context.Context, linkInfo parser.GetLinkInfoRes
 	return data, stats, nil
 }
 
+// retryable reports whether fetching again may help: the source throttled or
+// refused the bot, or the error is outside the taxonomy. A private, deleted or
+// unsupported post fails the same way on every attempt.
+func retryable(err error) bool {
+	switch extractor.Kind(err) {
+	case nil, extractor.ErrRateLimited, extractor.ErrBlocked:
+		return true
+	}
+	return false
+}
+
 func (s *handler) checkLimit(ctx context.Context, chatID int64) error {
 	return s.lim.Allow(ctx, strconv.Itoa(int(chatID)))
 }
 
+// userMessages explains the extractor error taxonomy to users.
+var userMessages = map[error]string{
+	extractor.ErrUnsupportedURL:  "This kind of link is not supported",
+	extractor.ErrNotSupported:    "Couldn't find any media by this link",
+	extractor.ErrBlocked:         "The source refused to share this media, please try again later",
+	extractor.ErrPrivate:         "This content is private",
+	extractor.ErrNotFound:        "This content doesn't exist or has been deleted",
+	extractor.ErrLoginRequired:   "This content is only available to signed-in users",
+	extractor.ErrRateLimited:     "Too many requests to the source, please try again later",
+	extractor.ErrGeoBlocked:      "This content is not available in the bot's region",
+	extractor.ErrUpstreamChanged: "The source has changed and this link can't be processed yet",
+}
+
+// userMessage returns the text shown to the user for err. Errors outside the
+// taxonomy carry internal details, so they get a generic message.
+func userMessage(err error) string {
+	if msg, ok := userMessages[extractor.Kind(err)]; ok {
+		return msg
+	}
+	if errors.Is(err, parser.ErrSourceUnavailable) {
+		return "This source is temporarily unavailable, please try again later"
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "The source took too long to respond, please try again"
+	}
+	return "Failed to fetch media, please try again"
+}
+
 func replyError(c telebot.Context, text string) error {
 	_, err := c.Bot().Reply(c.Message(), fmt.Sprintf("⚠️ *Oops, ERROR!*\n\n`%s`", text), telebot.ModeMarkdown)
 	if err != nil {
@@ -428,7 +494,10 @@ func replyText(tgCtx telebot.Context, text string) error {
 	return nil
 }
 
-func (s *handler) processYoutube(tgCtx telebot.Context, data *models.Media) error {
+// processFormats replies with the thumbnail and a list of download links, one
+// per variant of the items, for sources that offer their formats to choose
+// from.
+func (s *handler) processFormats(tgCtx telebot.Context, data *models.Media) error {
 	// send thumbnail
 	if data.Url != "" {
 		if _, err := s.bot.Send(tgCtx.Message().Chat, &telebot.Photo{
@@ -447,70 +516,82 @@ func (s *handler) processYoutube(tgCtx telebot.Context, data *models.Media) erro
 		respText += data.Caption + "\n\n"
 	}
 
-	fnVideoFormatter := func(item *models.MediaItem) {
-		downloadLink := item.Url
+	fnVideoFormatter := func(v *models.Variant) {
+		downloadLink := v.Url
 
 		noAudioStr := ""
-		if item.VideoWithoutAudio {
+		if !v.HasAudio {
 			noAudioStr = " 🔇 "
 		}
 
-		if item.ContentLength == 0 {
+		if v.ContentLength == 0 {
 			respText += fmt.Sprintf(
 				"🔹 *%s*%s [Download](%s)\n`(%s)`\n\n",
-				item.Quality,
+				v.Quality,
 				noAudioStr,
 				downloadLink,
-				item.MimeType,
+				formatType(v),
 			)
 		} else {
 			respText += fmt.Sprintf(
 				"🔹 *%s*%s [Download %.2fMB](%s)\n`(%s)`\n\n",
-				item.Quality,
+				v.Quality,
 				noAudioStr,
-				float64(item.ContentLength)/1024/1024,
+				float64(v.ContentLength)/1024/1024,
 				downloadLink,
-				item.MimeType,
+				formatType(v),
 			)
 		}
 	}
 
-	fnAudioFormatter := func(item *models.MediaItem) {
+	fnAudioFormatter := func(v *models.Variant) {
 		respText += fmt.Sprintf(
 			"🔸 %s [Download %.2fMB](%s) `(%s)`\n",
-			item.Quality,
-			float64(item.ContentLength)/1024/1024,
-			item.Url,
-			item.MimeType,
+			v.Quality,
+			float64(v.ContentLength)/1024/1024,
+			v.Url,
+			formatType(v),
 		)
 	}
 
-	videoItems := utils.FilterArray(data.Items, func(v *models.MediaItem) bool {
-		return v.Type == "video"
-	})
-
-	audioItems := utils.FilterArray(data.Items, func(v *models.MediaItem) bool {
-		return v.Type == "audio"
-	})
+	var videoVariants, audioVariants []*models.Variant
+	for _, item := range data.Items {
+		for _, v := range item.AllVariants() {
+			switch {
+			case v.HasVideo:
+				videoVariants = append(videoVariants, v)
+			case v.HasAudio:
+				audioVariants = append(audioVariants, v)
+			}
+		}
+	}
 
-	if len(videoItems) > 0 {
+	if len(videoVariants) > 0 {
 		respText += "🎥 *Video*\n\n"
-		for _, item := range videoItems {
-			fnVideoFormatter(item)
+		for _, v := range videoVariants {
+			fnVideoFormatter(v)
 		}
 		respText += "\n"
 	}
 
-	if len(audioItems) > 0 {
+	if len(audioVariants) > 0 {
 		respText += "🎶 *Audio*\n\n"
-		for _, item := range audioItems {
-			fnAudioFormatter(item)
+		for _, v := range audioVariants {
+			fnAudioFormatter(v)
 		}
 	}
 
 	return replyText(tgCtx, respText)
 }
 
+// formatType describes a variant as its MIME type and, when known, codecs.
+func formatType(v *models.Variant) string {
+	if v.Codec == "" {
+		return v.MimeType
+	}
+	return fmt.Sprintf("%s; codecs=%s", v.MimeType, v.Codec)
+}
+
 // processGenericMedia handles media from all sources (Instagram, TikTok, Twitter, etc.)
 func (s *handler) processGenericMedia(ctx context.Context, tgCtx telebot.Context, data *models.Media) error {
 	if err := s.sendMediaContent(ctx, tgCtx, data); err != nil {
@@ -521,13 +602,7 @@ func (s *handler) processGenericMedia(ctx context.Context, tgCtx telebot.Context
 	// and must NOT be parsed as Markdown — stray/unbalanced markup (a lone `*`,
 	// `_`, `[`, ...) makes Telegram reject the message with a 400 entity-parse
 	// error, so it is sent as plain text.
-	var captionText string
-	if data.Title != "" {
-		captionText = data.Title + "\n\n"
-	}
-	if data.Caption != "" {
-		captionText += data.Caption
-	}
+	captionText := joinNonEmpty("\n\n", byline(data), data.Title, data.Caption)
 
 	if captionText != "" {
 		if err := retry.New().Do(func() error {
@@ -541,6 +616,28 @@ func (s *handler) processGenericMedia(ctx context.Context, tgCtx telebot.Context
 	return nil
 }
 
+// byline describes who posted the media and when, like "@user · 2 Jan 2006",
+// with the parts the source told; "" when it told neither.
+func byline(data *models.Media) string {
+	var author string
+	if data.Author != "" {
+		author = "@" + data.Author
+		if data.Owner != nil && data.Owner.IsVerified {
+			author += " ✔️"
+		}
+	}
+	var date string
+	if data.TakenAt > 0 {
+		date = time.Unix(data.TakenAt, 0).UTC().Format("2 Jan 2006")
+	}
+	return joinNonEmpty(" · ", author, date)
+}
+
+// joinNonEmpty joins the non-empty parts with sep.
+func joinNonEmpty(sep string, parts ...string) string {
+	return strings.Join(slices.DeleteFunc(parts, func(s string) bool { return s == "" }), sep)
+}
+
 // truncateRunes returns text limited to maxRunes runes, appending an ellipsis if it was cut.
 func truncateRunes(text string, maxRunes int) string {
 	runes := []rune(text)
@@ -607,13 +704,14 @@ func tooLargeResult(sourceURL string, limit int64) telebot.Result {
 }
 
 func (s *handler) replyTooLarge(tgCtx telebot.Context, sourceURL string) error {
-	text := tooLargeText(sourceURL, maxFileSize)
+	limit := s.maxUploadSize()
+	text := tooLargeText(sourceURL, limit)
 	if err := retry.New().Do(func() error {
 		_, err := s.bot.Reply(tgCtx.Message(), text, telebot.ModeMarkdown)
 		return err
 	}); err != nil {
 		s.logger.Warnf("reply too-large markdown failed, falling back to plain reply: %v", err)
-		if _, fallbackErr := s.bot.Reply(tgCtx.Message(), "file is larger than 50MB, telegram bots can't send it"); fallbackErr != nil {
+		if _, fallbackErr := s.bot.Reply(tgCtx.Message(), fmt.Sprintf("file is larger than %dMB, telegram bots can't send it", limit/1024/1024)); fallbackErr != nil {
 			return fmt.Errorf("reply too-large failed: %w (after markdown error: %v)", fallbackErr, err)
 		}
 	}
@@ -638,87 +736,203 @@ func videoFromItem(item *models.MediaItem, body io.Reader) *telebot.Video {
 	}
 }
 
+// audioFromItem builds the audio upload for a media item. Telegram shows the
+// title and performer in its player instead of the file name.
+func audioFromItem(item *models.MediaItem, body io.Reader) *telebot.Audio {
+	return &telebot.Audio{
+		File:      telebot.FromReader(body),
+		Duration:  item.Duration,
+		Title:     item.Title,
+		Performer: item.Artist,
+		MIME:      item.MimeType,
+	}
+}
+
+// uploadVariant picks the variant of item the bot uploads, the best one within
+// limit (see media.Select). An item with a single format is not probed for
+// its size: there is nothing to choose from, and the download rechecks the
+// size before any bytes are sent.
+func uploadVariant(ctx context.Context, loader media.Loader, item *models.MediaItem, limit int64) (*models.MediaItem, bool) {
+	if len(item.Variants) == 0 {
+		return item, item.ContentLength <= limit
+	}
+	return media.Select(ctx, loader, item, limit)
+}
+
+// maxUploadSize returns the limit for files the bot uploads: the configured
+// one for a local Bot API server, maxFileSize otherwise.
+func (s *handler) maxUploadSize() int64 {
+	if s.config != nil && s.config.TelegramMaxUploadSize > 0 {
+		return s.config.TelegramMaxUploadSize
+	}
+	return maxFileSize
+}
+
+// sendMediaContent sends a single photo or video as is and several as albums.
+// Audio items, which Telegram can't mix with photos and videos in an album,
+// are sent one by one: as the media of an audio-only link, or after the
+// photos and videos as their soundtrack (see sendSoundtrack).
 func (s *handler) sendMediaContent(ctx context.Context, tgCtx telebot.Context, data *models.Media) error {
 	source := string(data.Source)
-	if len(data.Items) == 1 {
-		mediaItem := data.Items[0]
+	limit := s.maxUploadSize()
 
-		if mediaItem.ContentLength > maxFileSize {
-			metrics.ObserveDownloadFailure(source, metrics.ReasonSizeLimit)
-			return s.replyTooLarge(tgCtx, mediaItem.Url)
+	visual, audio := splitAudio(data.Items)
+	if len(visual) == 0 {
+		for _, item := range audio {
+			if err := s.sendSingle(ctx, tgCtx, source, item, limit); err != nil {
+				return err
+			}
 		}
+		return nil
+	}
 
-		content, err := s.loader.Open(ctx, mediaItem)
-		if err != nil {
-			metrics.ObserveDownloadFailure(source, metrics.ReasonOpen)
+	if len(visual) == 1 {
+		if err := s.sendSingle(ctx, tgCtx, source, visual[0], limit); err != nil {
 			return err
 		}
+	} else {
+		for chunk := range slices.Chunk(visual, 10) {
+			// Album items are buffered in memory, so a raised upload limit
+			// doesn't apply to them.
+			album, err := generateAlbumFromMedia(ctx, s.loader, source, chunk, min(limit, maxFileSize))
+			if err != nil {
+				return fmt.Errorf("couldn't generate the album: %w", err)
+			}
 
-		// Open reports the real size from the response header — recheck before streaming
-		if content.ContentLength > maxFileSize {
-			_ = content.Body.Close()
-			metrics.ObserveDownloadFailure(source, metrics.ReasonSizeLimit)
-			return s.replyTooLarge(tgCtx, mediaItem.Url)
-		}
-
-		body := metrics.TrackDownload(source, content.Body)
-		defer body.Close()
-
-		if content.ContentLength > 0 {
-			metrics.MediaSizeBytes.Observe(float64(content.ContentLength))
-		}
-
-		// handle video
-		if mediaItem.Type.IsVideo() {
 			sendErr := retry.New().Do(func() error {
-				_, err := s.bot.Send(tgCtx.Message().Chat, videoFromItem(mediaItem, body))
+				_, err := s.bot.SendAlbum(tgCtx.Message().Chat, album)
 				return err
 			})
-			metrics.ObserveTelegramDelivery(source, "video", sendErr)
+			metrics.ObserveTelegramDelivery(source, "album", sendErr)
 			if sendErr != nil {
-				return fmt.Errorf("couldn't send the single video: %w", sendErr)
+				return fmt.Errorf("couldn't send the album: %w", sendErr)
 			}
 		}
+	}
 
-		// handle photo
-		if mediaItem.Type.IsPhoto() {
-			sendErr := retry.New().Do(func() error {
-				_, err := s.bot.Send(tgCtx.Message().Chat, &telebot.Photo{
-					File:   telebot.FromReader(body),
-					Width:  mediaItem.Width,
-					Height: mediaItem.Height,
-				})
-				return err
-			})
-			metrics.ObserveTelegramDelivery(source, "photo", sendErr)
-			if sendErr != nil {
-				return fmt.Errorf("couldn't send the single photo: %w", sendErr)
-			}
+	for _, item := range audio {
+		s.sendSoundtrack(ctx, tgCtx, source, item, limit)
+	}
+	return nil
+}
+
+// sendSoundtrack sends the soundtrack of the media just delivered, when
+// enabled. It is an extra: a soundtrack that fails or is too large is only
+// logged (and counted by the download and delivery metrics), the user already
+// has the media.
+func (s *handler) sendSoundtrack(ctx context.Context, tgCtx telebot.Context, source string, item *models.MediaItem, limit int64) {
+	if s.config == nil || !s.config.TelegramSoundtrack {
+		return
+	}
+	if err := s.upload(ctx, tgCtx, source, item, limit); err != nil {
+		s.logger.Warnf("couldn't send the soundtrack of %s media: %v", source, err)
+	}
+}
+
+// splitAudio separates the audio items from the photos and videos.
+func splitAudio(items []*models.MediaItem) (visual, audio []*models.MediaItem) {
+	for _, item := range items {
+		if item.Type.IsAudio() {
+			audio = append(audio, item)
+		} else {
+			visual = append(visual, item)
 		}
+	}
+	return visual, audio
+}
 
-		return nil
+// tooLargeError is returned by upload for an item over the upload limit.
+type tooLargeError struct {
+	url string
+}
+
+func (e *tooLargeError) Error() string {
+	return "file is too large to upload: " + e.url
+}
+
+// sendSingle uploads one item as a message of its own, or replies with a link
+// to it when it is too large.
+func (s *handler) sendSingle(ctx context.Context, tgCtx telebot.Context, source string, item *models.MediaItem, limit int64) error {
+	err := s.upload(ctx, tgCtx, source, item, limit)
+	var tooLarge *tooLargeError
+	if errors.As(err, &tooLarge) {
+		return s.replyTooLarge(tgCtx, tooLarge.url)
 	}
+	return err
+}
 
-	for chunk := range slices.Chunk(data.Items, 10) {
-		album, err := generateAlbumFromMedia(ctx, s.loader, source, chunk)
-		if err != nil {
-			return fmt.Errorf("couldn't generate the album: %w", err)
+// upload uploads one item as a message of its own.
+func (s *handler) upload(ctx context.Context, tgCtx telebot.Context, source string, item *models.MediaItem, limit int64) error {
+	mediaItem, fits := uploadVariant(ctx, s.loader, item, limit)
+	if !fits {
+		metrics.ObserveDownloadFailure(source, metrics.ReasonSizeLimit)
+		return &tooLargeError{url: mediaItem.Url}
+	}
+
+	content, err := s.loader.Open(ctx, mediaItem)
+	if err != nil {
+		metrics.ObserveDownloadFailure(source, metrics.ReasonOpen)
+		return err
+	}
+
+	// Open reports the real size from the response header — recheck before streaming
+	if content.ContentLength > limit {
+		_ = content.Body.Close()
+		metrics.ObserveDownloadFailure(source, metrics.ReasonSizeLimit)
+		return &tooLargeError{url: mediaItem.Url}
+	}
+
+	body := metrics.TrackDownload(source, content.Body)
+	defer body.Close()
+
+	if content.ContentLength > 0 {
+		metrics.MediaSizeBytes.Observe(float64(content.ContentLength))
+	}
+
+	// handle video
+	if mediaItem.Type.IsVideo() {
+		sendErr := retry.New().Do(func() error {
+			_, err := s.bot.Send(tgCtx.Message().Chat, videoFromItem(mediaItem, body))
+			return err
+		})
+		metrics.ObserveTelegramDelivery(source, "video", sendErr)
+		if sendErr != nil {
+			return fmt.Errorf("couldn't send the single video: %w", sendErr)
+		}
+	}
+
+	// handle photo
+	if mediaItem.Type.IsPhoto() {
+		sendErr := retry.New().Do(func() error {
+			_, err := s.bot.Send(tgCtx.Message().Chat, &telebot.Photo{
+				File:   telebot.FromReader(body),
+				Width:  mediaItem.Width,
+				Height: mediaItem.Height,
+			})
+			return err
+		})
+		metrics.ObserveTelegramDelivery(source, "photo", sendErr)
+		if sendErr != nil {
+			return fmt.Errorf("couldn't send the single photo: %w", sendErr)
 		}
+	}
 
+	// handle audio
+	if mediaItem.Type.IsAudio() {
 		sendErr := retry.New().Do(func() error {
-			_, err := s.bot.SendAlbum(tgCtx.Message().Chat, album)
+			_, err := s.bot.Send(tgCtx.Message().Chat, audioFromItem(mediaItem, body))
 			return err
 		})
-		metrics.ObserveTelegramDelivery(source, "album", sendErr)
+		metrics.ObserveTelegramDelivery(source, "audio", sendErr)
 		if sendErr != nil {
-			return fmt.Errorf("couldn't send the album: %w", sendErr)
+			return fmt.Errorf("couldn't send the single audio: %w", sendErr)
 		}
 	}
 
 	return nil
 }
 
-func generateAlbumFromMedia(ctx context.Context, loader media.Loader, source string, items []*models.MediaItem) (telebot.Album, error) {
+func generateAlbumFromMedia(ctx context.Context, loader media.Loader, source string, items []*models.MediaItem, limit int64) (telebot.Album, error) {
 	album := util.NewSliceWithLength[telebot.Inputtable](len(items))
 
 	eg := errgroup.Group{}
@@ -726,6 +940,12 @@ func generateAlbumFromMedia(ctx context.Context, loader media.Loader, source str
 
 	for idx, item := range items {
 		eg.Go(func() error {
+			item, fits := uploadVariant(ctx, loader, item, limit)
+			if !fits {
+				metrics.ObserveDownloadFailure(source, metrics.ReasonSizeLimit)
+				return fmt.Errorf("media item exceeds %d bytes", limit)
+			}
+
 			content, err := loader.Open(ctx, item)
 			if err != nil {
 				metrics.ObserveDownloadFailure(source, metrics.ReasonOpen)
@@ -733,18 +953,22 @@ func generateAlbumFromMedia(ctx context.Context, loader media.Loader, source str
 			}
 
 			// Guard before buffering the whole item into memory.
-			if content.ContentLength > maxFileSize {
+			if content.ContentLength > limit {
 				_ = content.Body.Close()
 				metrics.ObserveDownloadFailure(source, metrics.ReasonSizeLimit)
-				return fmt.Errorf("media item exceeds %d bytes", int64(maxFileSize))
+				return fmt.Errorf("media item exceeds %d bytes", limit)
 			}
 
 			body := metrics.TrackDownload(source, content.Body)
 			defer body.Close()
-			data, err := io.ReadAll(body)
+			// The source may not tell the size, or lie about it.
+			data, err := io.ReadAll(io.LimitReader(body, limit+1))
 			if err != nil {
 				return err
 			}
+			if int64(len(data)) > limit {
+				return fmt.Errorf("media item exceeds %d bytes", limit)
+			}
 			buf := bytes.NewReader(data)
 
 			if item.Type.IsVideo() {
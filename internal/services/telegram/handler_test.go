@@ -1,6 +1,83 @@
 package telegram
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sxwebdev/downloaderbot/internal/models"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
+)
+
+func TestUserMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"private", fmt.Errorf("failed to get media: %w", fmt.Errorf("failed to get post: %w: %w", extractor.ErrPrivate, errors.New("raw"))), userMessages[extractor.ErrPrivate]},
+		{"unsupported", fmt.Errorf("%w: https://instagram.com/someone", extractor.ErrUnsupportedURL), userMessages[extractor.ErrUnsupportedURL]},
+		{"timeout", fmt.Errorf("failed to get media: %w", context.DeadlineExceeded), "The source took too long to respond, please try again"},
+		{"internal details stay hidden", errors.New("dial tcp 10.0.0.1:443: connection refused"), "Failed to fetch media, please try again"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := userMessage(tc.err); got != tc.want {
+				t.Fatalf("userMessage(%v) = %q, want %q", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"untyped", errors.New("empty data items"), true},
+		{"rate limited", fmt.Errorf("get post: %w", extractor.ErrRateLimited), true},
+		{"blocked", fmt.Errorf("get post: %w", extractor.ErrBlocked), true},
+		{"private", fmt.Errorf("get post: %w", extractor.ErrPrivate), false},
+		{"not found", fmt.Errorf("get post: %w", extractor.ErrNotFound), false},
+		{"unsupported", fmt.Errorf("%w: https://instagram.com/someone", extractor.ErrUnsupportedURL), false},
+		{"login required", extractor.ErrLoginRequired, false},
+		{"geo blocked", extractor.ErrGeoBlocked, false},
+		{"upstream changed", extractor.ErrUpstreamChanged, false},
+		{"not supported", extractor.ErrNotSupported, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := retryable(tc.err); got != tc.want {
+				t.Fatalf("retryable(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestByline(t *testing.T) {
+	tests := []struct {
+		name string
+		data models.Media
+		want string
+	}{
+		{"author and date", models.Media{Author: "someone", TakenAt: 1726000000}, "@someone · 10 Sep 2024"},
+		{"verified", models.Media{Author: "instagram", Owner: &models.Owner{Username: "instagram", IsVerified: true}}, "@instagram ✔️"},
+		{"date only", models.Media{TakenAt: 1726000000}, "10 Sep 2024"},
+		{"unknown", models.Media{}, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := byline(&tc.data); got != tc.want {
+				t.Fatalf("byline() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
 
 func TestTruncateRunes(t *testing.T) {
 	tests := []struct {
@@ -2,7 +2,6 @@ package telegram
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -48,6 +47,14 @@ func (f *fakeLoader) ContentLength(context.Context, *models.MediaItem) (int64, e
 	return f.size, nil
 }
 
+func (f *fakeLoader) Probe(ctx context.Context, item *models.MediaItem) (*media.Info, error) {
+	size, err := f.ContentLength(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+	return &media.Info{ContentLength: size}, nil
+}
+
 // TestInlineResultFor_URLSizeLimit guards the reported bug: an Instagram reel
 // that the bot delivers fine in a direct message never appears at all in inline
 // mode. Telegram fetches an inline result from the URL itself and caps that at
@@ -76,10 +83,14 @@ func TestInlineResultFor_URLSizeLimit(t *testing.T) {
 		},
 		{name: "over the upload cap too", size: 60 * mb, wantLink: true},
 		{
-			// The CDN refused a HEAD, so the size is unknown. Offering the video is
-			// the better guess — assuming "too large" would downgrade every result
-			// from a source that does not answer HEAD.
-			name: "unknown size still offers the video", sizeErr: errors.New("no HEAD"), wantLink: false,
+			// The CDN answered without a size. Offering the video is the better
+			// guess — assuming "too large" would downgrade every result from a
+			// source that does not report one.
+			name: "unknown size still offers the video", size: -1, wantLink: false,
+		},
+		{
+			// A gone file is not offered as a playable video Telegram can't fetch.
+			name: "failed probe offers the link", sizeErr: &media.StatusError{StatusCode: 404, Status: "404 Not Found"}, wantLink: true,
 		},
 	}
 
@@ -327,3 +338,37 @@ func TestMaxURLFileSize(t *testing.T) {
 		t.Error("the URL limit must be stricter than the upload limit")
 	}
 }
+
+// TestInlineResultFor_PicksVariantWithinLimit checks that a video offered in
+// several qualities is sent in the best one Telegram can fetch, and the
+// download link is the fallback only when none fits.
+func TestInlineResultFor_PicksVariantWithinLimit(t *testing.T) {
+	const mb = 1024 * 1024
+	item := &models.MediaItem{
+		Type: models.MediaTypeVideo,
+		Url:  "https://cdn.example/1080.mp4",
+		Variants: []*models.Variant{
+			{Url: "https://cdn.example/1080.mp4", Height: 1080, Width: 1920, ContentLength: 80 * mb, HasVideo: true, HasAudio: true},
+			{Url: "https://cdn.example/720.mp4", Height: 720, Width: 1280, ContentLength: 15 * mb, HasVideo: true, HasAudio: true},
+		},
+	}
+
+	h := &handler{loader: &fakeLoader{}}
+	result, ok := h.inlineResultFor(t.Context(), item, 0, "")
+	if !ok {
+		t.Fatal("inlineResultFor reported the item as un-offerable")
+	}
+	video, isVideo := result.(*telebot.VideoResult)
+	if !isVideo {
+		t.Fatalf("result type = %T, want *telebot.VideoResult", result)
+	}
+	if video.URL != "https://cdn.example/720.mp4" || video.Height != 720 {
+		t.Fatalf("got %s at %dp, want the 720p variant", video.URL, video.Height)
+	}
+
+	item.Variants[1].ContentLength = 30 * mb
+	result, _ = h.inlineResultFor(t.Context(), item, 0, "")
+	if _, isArticle := result.(*telebot.ArticleResult); !isArticle {
+		t.Fatalf("result type = %T, want a download-link ArticleResult when no variant fits", result)
+	}
+}
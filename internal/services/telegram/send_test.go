@@ -65,6 +65,38 @@ func TestVideoFromItem(t *testing.T) {
 	}
 }
 
+func TestAudioFromItem(t *testing.T) {
+	item := &models.MediaItem{
+		Type:     models.MediaTypeAudio,
+		Url:      "https://cdn.example/track.m4a",
+		MimeType: "audio/mp4",
+		Duration: 31,
+		Title:    "Golden Hour",
+		Artist:   "JVKE",
+	}
+	audio := audioFromItem(item, strings.NewReader("payload"))
+	if audio.Title != item.Title || audio.Performer != item.Artist || audio.Duration != item.Duration || audio.MIME != item.MimeType {
+		t.Fatalf("audio = %+v, want the track metadata of %+v", audio, item)
+	}
+	if audio.File.FileReader == nil {
+		t.Error("File carries no reader, so nothing would be uploaded")
+	}
+}
+
+func TestSplitAudio(t *testing.T) {
+	video := &models.MediaItem{Type: models.MediaTypeVideo}
+	photo := &models.MediaItem{Type: models.MediaTypePhoto}
+	audio := &models.MediaItem{Type: models.MediaTypeAudio}
+
+	visual, tracks := splitAudio([]*models.MediaItem{video, audio, photo})
+	if len(visual) != 2 || visual[0] != video || visual[1] != photo {
+		t.Fatalf("visual = %v, want the video and the photo in order", visual)
+	}
+	if len(tracks) != 1 || tracks[0] != audio {
+		t.Fatalf("audio = %v, want the audio item", tracks)
+	}
+}
+
 // TestVideoFromItem_MatchesAcrossPaths pins the single-video and album paths to
 // the same description of a file. They used to build their own telebot.Video
 // literals, which is how one could gain a field the other lacked.
@@ -105,7 +137,7 @@ func TestGenerateAlbumTracksCompletedDownloads(t *testing.T) {
 		source, appmetrics.OutcomeSuccess, appmetrics.ReasonNone,
 	))
 
-	album, err := generateAlbumFromMedia(t.Context(), loader, source, items)
+	album, err := generateAlbumFromMedia(t.Context(), loader, source, items, maxFileSize)
 	if err != nil {
 		t.Fatalf("generateAlbumFromMedia: %v", err)
 	}
@@ -136,7 +168,7 @@ func TestGenerateAlbumTracksOpenFailure(t *testing.T) {
 	_, err := generateAlbumFromMedia(t.Context(), &fakeLoader{openErr: openErr}, source, []*models.MediaItem{{
 		Type: models.MediaTypePhoto,
 		Url:  "https://cdn.example/1.jpg",
-	}})
+	}}, maxFileSize)
 	if !errors.Is(err, openErr) {
 		t.Fatalf("generateAlbumFromMedia error = %v, want %v", err, openErr)
 	}
@@ -146,3 +178,16 @@ func TestGenerateAlbumTracksOpenFailure(t *testing.T) {
 		t.Errorf("failed downloads delta = %v, want 1", got)
 	}
 }
+
+func TestGenerateAlbumLimitsUnknownSize(t *testing.T) {
+	// The source doesn't report the size, so only reading tells it.
+	loader := &fakeLoader{size: -1, payload: "eleven byte"}
+	items := []*models.MediaItem{{Type: models.MediaTypePhoto, Url: "https://cdn.example/1.jpg"}}
+
+	if _, err := generateAlbumFromMedia(t.Context(), loader, "test_album_size", items, 10); err == nil {
+		t.Fatal("want an error for an item over the limit")
+	}
+	if _, err := generateAlbumFromMedia(t.Context(), loader, "test_album_size", items, 11); err != nil {
+		t.Fatalf("generateAlbumFromMedia: %v", err)
+	}
+}
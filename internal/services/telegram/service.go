@@ -40,6 +40,7 @@ func (s Service) Name() string { return s.name }
 
 func (s *Service) Start(ctx context.Context) error {
 	bot, err := telebot.NewBot(telebot.Settings{
+		URL:    s.config.TelegramBotApiUrl,
 		Token:  s.config.TelegramBotApiToken,
 		Poller: &telebot.LongPoller{Timeout: 10 * time.Second},
 		// Verbose: s.config.EnvCI == "local",
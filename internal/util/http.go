@@ -1,8 +1,12 @@
 package util
 
 import (
+	"errors"
+	"fmt"
 	"net"
 	"net/http"
+	"net/netip"
+	"syscall"
 	"time"
 )
 
@@ -31,3 +35,60 @@ func DefaultHttpClient() *http.Client { return defaultClient }
 // so callers can build their own *http.Client (e.g. with a cookie jar) while
 // reusing the same connection pool and timeouts.
 func DefaultTransport() *http.Transport { return defaultTransport }
+
+// ErrPrivateAddress is returned by the public clients when a host resolves to
+// a loopback, private, link-local, multicast, shared (CGNAT), benchmarking or
+// unspecified address.
+var ErrPrivateAddress = errors.New("address is not public")
+
+var publicTransport = func() *http.Transport {
+	t := defaultTransport.Clone()
+	t.DialContext = (&net.Dialer{
+		Timeout:   5 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   publicOnly,
+	}).DialContext
+	return t
+}()
+
+var publicClient = &http.Client{
+	Timeout:   30 * time.Second,
+	Transport: publicTransport,
+}
+
+// PublicHttpClient returns a client like the default one that only connects to
+// public addresses, for links taken from users to hosts nobody vetted. The
+// address is checked after DNS resolution, redirects included, so neither a
+// hostname nor a redirect can point it at the bot's own network.
+func PublicHttpClient() *http.Client { return publicClient }
+
+// publicOnly is a net.Dialer Control refusing the addresses ErrPrivateAddress
+// describes. It runs once per address tried, after resolution.
+func publicOnly(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("invalid address %q: %w", host, err)
+	}
+	ip = ip.Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsMulticast() {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, ip)
+	}
+	for _, p := range nonPublicPrefixes {
+		if p.Contains(ip) {
+			return fmt.Errorf("%w: %s", ErrPrivateAddress, ip)
+		}
+	}
+	return nil
+}
+
+// nonPublicPrefixes are the special-purpose ranges netip has no predicate for.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"), // shared address space (CGNAT)
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+}
@@ -0,0 +1,40 @@
+package util
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPublicOnly(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		private bool
+	}{
+		{"public v4", "93.184.216.34:443", false},
+		{"public v6", "[2606:2800:220:1:248:1893:25c8:1946]:443", false},
+		{"loopback", "127.0.0.1:80", true},
+		{"loopback v6", "[::1]:80", true},
+		{"private", "10.1.2.3:80", true},
+		{"private mapped", "[::ffff:192.168.1.1]:80", true},
+		{"link local", "169.254.169.254:80", true},
+		{"unspecified", "0.0.0.0:80", true},
+		{"this network", "0.1.2.3:80", true},
+		{"multicast", "224.0.0.251:5353", true},
+		{"multicast v6", "[ff05::1]:80", true},
+		{"cgnat", "100.100.100.200:80", true},
+		{"cgnat edge", "100.127.255.255:80", true},
+		{"after cgnat", "100.128.0.1:80", false},
+		{"benchmarking", "198.19.0.1:80", true},
+		{"after benchmarking", "198.20.0.1:80", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := publicOnly("tcp", tc.address, nil)
+			if got := errors.Is(err, ErrPrivateAddress); got != tc.private {
+				t.Fatalf("publicOnly(%q) = %v, want private %v", tc.address, err, tc.private)
+			}
+		})
+	}
+}
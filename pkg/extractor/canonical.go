@@ -0,0 +1,75 @@
+package extractor
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Canonicalizer is the optional interface an Extractor implements when links of
+// different shapes point at the same media (/reel/X and /p/X, youtu.be/ID and
+// /watch?v=ID). Canonicalize returns the one form they all share, which
+// identifies the media in caches, in-flight deduplication and logs. Extractors
+// that don't implement it get NormalizeURL.
+type Canonicalizer interface {
+	Canonicalize(rawURL string) (string, error)
+}
+
+// trackingParams are query parameters that only tell the source who shared a
+// link; links differing in them point at the same media.
+var trackingParams = map[string]bool{
+	"igsh":    true,
+	"igshid":  true,
+	"si":      true,
+	"feature": true,
+	"fbclid":  true,
+	"gclid":   true,
+	"_r":      true,
+	"_t":      true,
+}
+
+// Canonicalize returns the canonical form of rawURL for e.
+func Canonicalize(e Extractor, rawURL string) (string, error) {
+	if c, ok := e.(Canonicalizer); ok {
+		return c.Canonicalize(rawURL)
+	}
+	return NormalizeURL(rawURL)
+}
+
+// NormalizeURL is the canonical form of links that carry no better identity:
+// https, the host normalized as the registry does (lowercase, no "www.", "m."
+// or "mobile."), no trailing slash, no fragment, and the query without
+// tracking parameters, utm_* ones and extra, sorted by name.
+func NormalizeURL(rawURL string, extra ...string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("no host in %q", rawURL)
+	}
+
+	query := u.Query()
+	for name := range query {
+		if trackingParams[name] || strings.HasPrefix(name, "utm_") {
+			query.Del(name)
+		}
+	}
+	for _, name := range extra {
+		query.Del(name)
+	}
+
+	host := normalizeHost(u.Hostname())
+	if port := u.Port(); port != "" {
+		host += ":" + port
+	}
+
+	canonical := url.URL{
+		Scheme:   "https",
+		Host:     host,
+		Path:     strings.TrimRight(u.Path, "/"),
+		RawPath:  strings.TrimRight(u.RawPath, "/"),
+		RawQuery: query.Encode(), // Encode sorts by name
+	}
+	return canonical.String(), nil
+}
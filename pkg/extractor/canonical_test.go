@@ -0,0 +1,56 @@
+package extractor
+
+import "testing"
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"https://www.instagram.com/reel/Cxyz123/?igsh=abc&utm_source=ig_web_copy_link", "https://instagram.com/reel/Cxyz123"},
+		{"http://instagram.com/reel/Cxyz123#comments", "https://instagram.com/reel/Cxyz123"},
+		{"https://m.youtube.com/watch?v=dQw4w9WgXcQ&si=share&feature=youtu.be", "https://youtube.com/watch?v=dQw4w9WgXcQ"},
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ&list=PL1", "https://youtube.com/watch?list=PL1&v=dQw4w9WgXcQ"},
+		{"https://WWW.TikTok.com/@user/video/7123?_r=1&_t=8abc", "https://tiktok.com/@user/video/7123"},
+		{"https://example.test:8080/a%2Fb/", "https://example.test:8080/a%2Fb"},
+	}
+
+	for _, tc := range tests {
+		got, err := NormalizeURL(tc.in)
+		if err != nil {
+			t.Fatalf("NormalizeURL(%q): %v", tc.in, err)
+		}
+		if got != tc.want {
+			t.Errorf("NormalizeURL(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+
+	got, err := NormalizeURL("https://www.bilibili.com/video/BV1xx?p=2&spm_id_from=333&vd_source=x", "spm_id_from", "vd_source")
+	if err != nil || got != "https://bilibili.com/video/BV1xx?p=2" {
+		t.Errorf("extra params: got %q, %v", got, err)
+	}
+
+	if _, err := NormalizeURL("/relative/path"); err == nil {
+		t.Error("want an error for a link without host")
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	plain := &fakeExtractor{name: "plain"}
+	got, err := Canonicalize(plain, "https://www.example.test/a/?utm_medium=x")
+	if err != nil || got != "https://example.test/a" {
+		t.Fatalf("default canonical form: got %q, %v", got, err)
+	}
+
+	custom := &canonicalExtractor{fakeExtractor: fakeExtractor{name: "custom"}}
+	if got, _ := Canonicalize(custom, "https://example.test/a"); got != "custom:https://example.test/a" {
+		t.Fatalf("Canonicalizer not used: got %q", got)
+	}
+}
+
+type canonicalExtractor struct {
+	fakeExtractor
+}
+
+func (e *canonicalExtractor) Canonicalize(rawURL string) (string, error) {
+	return "custom:" + rawURL, nil
+}
@@ -0,0 +1,491 @@
+// Package declarative provides extractors defined in the config rather than in
+// code, for sites that serve their media as JSON: from an API endpoint, or
+// embedded in the HTML of the page.
+//
+// A definition names the hosts, a path pattern whose captures fill the
+// template of the URL to fetch, the request headers and, for HTML pages, a
+// regexp whose first group is the embedded JSON. gojq expressions then map
+// the document to the media: Items yields one value per item, and the item
+// expressions run on each of them. Every expression can read the link as
+// $url.
+package declarative
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/itchyny/gojq"
+	"github.com/sxwebdev/downloaderbot/internal/models"
+	"github.com/sxwebdev/downloaderbot/internal/util"
+	"github.com/sxwebdev/downloaderbot/pkg/browser"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
+)
+
+// DefaultTimeout bounds an extraction when its definition sets no timeout.
+const DefaultTimeout = 15 * time.Second
+
+// maxBodySize bounds how much of a response is read.
+const maxBodySize = 8 << 20
+
+// Definition describes a declarative extractor.
+type Definition struct {
+	// Name is the extractor name, also reported as the media source.
+	Name string
+	// Hosts are the hosts the extractor handles, wildcards included.
+	Hosts []string
+	// Path is a regexp the path of the link must match. Its groups, by name
+	// or by number, fill the placeholders of URL. Empty accepts any path.
+	Path string
+	// URL is the template of the URL to fetch: {name} and {1} are replaced
+	// with the groups of Path, {url} with the link itself. Empty fetches the
+	// link.
+	URL string
+	// Headers are sent with the request.
+	Headers map[string]string
+	// Embedded is a regexp over the response whose first group is the JSON
+	// document, for HTML pages. Empty reads the whole response as JSON.
+	Embedded string
+	// Timeout bounds an extraction, DefaultTimeout when zero.
+	Timeout time.Duration
+
+	// Title, Caption and Thumbnail are jq expressions over the document.
+	Title     string
+	Caption   string
+	Thumbnail string
+	// Items is a jq expression over the document yielding one value per item.
+	Items string
+	// Item maps each value yielded by Items to a media item.
+	Item ItemDefinition
+}
+
+// ItemDefinition holds the jq expressions of the item fields. Url is
+// required; Type defaults to video.
+type ItemDefinition struct {
+	Type          string
+	Url           string
+	Quality       string
+	MimeType      string
+	Width         string
+	Height        string
+	Duration      string
+	ContentLength string
+	Thumbnail     string
+}
+
+// Extractor implements the extractor.Extractor interface for a definition.
+type Extractor struct {
+	def      Definition
+	client   *http.Client
+	path     *regexp.Regexp
+	embedded *regexp.Regexp
+
+	title, caption, thumbnail *gojq.Code
+	items                     *gojq.Code
+	item                      itemCode
+}
+
+type itemCode struct {
+	typ, url, quality, mimeType, width, height, duration, contentLength, thumbnail *gojq.Code
+}
+
+// rePlaceholder matches the placeholders of a URL template.
+var rePlaceholder = regexp.MustCompile(`\{(\w+)\}`)
+
+// New compiles a definition, reporting the first mistake in it.
+func New(def Definition) (*Extractor, error) {
+	if def.Name == "" {
+		return nil, errors.New("extractor name is empty")
+	}
+	if len(def.Hosts) == 0 {
+		return nil, errors.New("no hosts")
+	}
+	if def.Items == "" || def.Item.Url == "" {
+		return nil, errors.New("items and item url expressions are required")
+	}
+	if def.Timeout <= 0 {
+		def.Timeout = DefaultTimeout
+	}
+
+	e := &Extractor{def: def, client: util.DefaultHttpClient()}
+
+	var err error
+	if def.Path != "" {
+		if e.path, err = regexp.Compile(def.Path); err != nil {
+			return nil, fmt.Errorf("path: %w", err)
+		}
+	}
+	for _, m := range rePlaceholder.FindAllStringSubmatch(def.URL, -1) {
+		if !e.hasGroup(m[1]) {
+			return nil, fmt.Errorf("url: placeholder %s is not a group of path", m[0])
+		}
+	}
+	if def.Embedded != "" {
+		if e.embedded, err = regexp.Compile(def.Embedded); err != nil {
+			return nil, fmt.Errorf("embedded: %w", err)
+		}
+		if e.embedded.NumSubexp() < 1 {
+			return nil, errors.New("embedded: the regexp has no group for the JSON document")
+		}
+	}
+
+	exprs := []struct {
+		name  string
+		query string
+		code  **gojq.Code
+	}{
+		{"title", def.Title, &e.title},
+		{"caption", def.Caption, &e.caption},
+		{"thumbnail", def.Thumbnail, &e.thumbnail},
+		{"items", def.Items, &e.items},
+		{"item type", def.Item.Type, &e.item.typ},
+		{"item url", def.Item.Url, &e.item.url},
+		{"item quality", def.Item.Quality, &e.item.quality},
+		{"item mime type", def.Item.MimeType, &e.item.mimeType},
+		{"item width", def.Item.Width, &e.item.width},
+		{"item height", def.Item.Height, &e.item.height},
+		{"item duration", def.Item.Duration, &e.item.duration},
+		{"item content length", def.Item.ContentLength, &e.item.contentLength},
+		{"item thumbnail", def.Item.Thumbnail, &e.item.thumbnail},
+	}
+	for _, expr := range exprs {
+		if expr.query == "" {
+			continue
+		}
+		if *expr.code, err = compile(expr.query); err != nil {
+			return nil, fmt.Errorf("%s: %w", expr.name, err)
+		}
+	}
+
+	return e, nil
+}
+
+// Register compiles the definition and adds the extractor to the registry.
+func Register(r *extractor.Registry, def Definition) error {
+	e, err := New(def)
+	if err != nil {
+		return fmt.Errorf("extractor %s: %w", def.Name, err)
+	}
+	return r.Register(e)
+}
+
+func compile(query string) (*gojq.Code, error) {
+	q, err := gojq.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	return gojq.Compile(q, gojq.WithVariables([]string{"$url"}))
+}
+
+// hasGroup reports whether the path regexp has the group a placeholder names.
+func (e *Extractor) hasGroup(name string) bool {
+	if name == "url" {
+		return true
+	}
+	if e.path == nil {
+		return false
+	}
+	if n, err := strconv.Atoi(name); err == nil {
+		return n >= 0 && n <= e.path.NumSubexp()
+	}
+	return e.path.SubexpIndex(name) >= 0
+}
+
+// Name returns the extractor name.
+func (e *Extractor) Name() string {
+	return e.def.Name
+}
+
+// Hosts returns the hosts of the definition.
+func (e *Extractor) Hosts() []string {
+	return e.def.Hosts
+}
+
+// Patterns limits the extractor to the links whose path matches the
+// definition.
+func (e *Extractor) Patterns() []extractor.Pattern {
+	if e.path == nil {
+		return nil
+	}
+	return []extractor.Pattern{{Path: e.path}}
+}
+
+// Capabilities declares publicly fetchable media delivered as it is.
+func (e *Extractor) Capabilities() extractor.Capabilities {
+	return extractor.Capabilities{
+		Inline:     true,
+		MediaTypes: []models.MediaType{models.MediaTypeVideo, models.MediaTypePhoto, models.MediaTypeAudio},
+		Output:     extractor.OutputItems,
+	}
+}
+
+// Extract fetches the document of the link and maps it to media.
+func (e *Extractor) Extract(ctx context.Context, link string) (*models.Media, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.def.Timeout)
+	defer cancel()
+
+	target, err := e.requestURL(link)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := e.fetch(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	media := &models.Media{
+		Source:     models.MediaSource(e.def.Name),
+		RequestUrl: link,
+	}
+	if media.Title, err = e.str(ctx, e.title, doc, link); err != nil {
+		return nil, err
+	}
+	if media.Caption, err = e.str(ctx, e.caption, doc, link); err != nil {
+		return nil, err
+	}
+	if media.Url, err = e.str(ctx, e.thumbnail, doc, link); err != nil {
+		return nil, err
+	}
+
+	iter := e.items.RunWithContext(ctx, doc, link)
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if err, isErr := v.(error); isErr {
+			return nil, e.queryError("items", err)
+		}
+		item, err := e.mediaItem(ctx, v, link)
+		if err != nil {
+			return nil, err
+		}
+		if item == nil {
+			continue
+		}
+		item.Id = strconv.Itoa(len(media.Items))
+		media.Items = append(media.Items, item)
+	}
+
+	if len(media.Items) == 0 {
+		return nil, fmt.Errorf("%w: no media found", extractor.ErrNotSupported)
+	}
+	media.Type = string(media.Items[0].Type)
+
+	return media, nil
+}
+
+// requestURL fills the URL template with the groups of the link path.
+func (e *Extractor) requestURL(link string) (string, error) {
+	if e.def.URL == "" {
+		return link, nil
+	}
+
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", extractor.ErrUnsupportedURL, err)
+	}
+	var groups []string
+	if e.path != nil {
+		if groups = e.path.FindStringSubmatch(u.EscapedPath()); groups == nil {
+			return "", fmt.Errorf("%w: path does not match", extractor.ErrUnsupportedURL)
+		}
+	}
+
+	return rePlaceholder.ReplaceAllStringFunc(e.def.URL, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		if name == "url" {
+			return url.QueryEscape(link)
+		}
+		idx, err := strconv.Atoi(name)
+		if err != nil {
+			idx = e.path.SubexpIndex(name)
+		}
+		return groups[idx]
+	}), nil
+}
+
+// fetch requests the URL and decodes the JSON document of the response.
+func (e *Extractor) fetch(ctx context.Context, target string) (any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", browser.UserAgent)
+	for k, v := range e.def.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", target, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		if kind := extractor.StatusError(resp.StatusCode); kind != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", target, kind)
+		}
+		return nil, fmt.Errorf("failed to fetch %s: status %d", target, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", target, err)
+	}
+
+	if e.embedded != nil {
+		m := e.embedded.FindSubmatch(body)
+		if m == nil {
+			return nil, fmt.Errorf("%w: no embedded document in %s", extractor.ErrUpstreamChanged, target)
+		}
+		body = m[1]
+	}
+
+	var doc any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("%w: invalid document: %w", extractor.ErrUpstreamChanged, err)
+	}
+	return doc, nil
+}
+
+// mediaItem maps a value yielded by Items. Values without a URL are skipped.
+func (e *Extractor) mediaItem(ctx context.Context, v any, link string) (*models.MediaItem, error) {
+	item := &models.MediaItem{Type: models.MediaTypeVideo}
+
+	strs := []struct {
+		code *gojq.Code
+		dst  *string
+	}{
+		{e.item.url, &item.Url},
+		{e.item.quality, &item.Quality},
+		{e.item.mimeType, &item.MimeType},
+		{e.item.thumbnail, &item.ThumbnailUrl},
+	}
+	for _, f := range strs {
+		s, err := e.str(ctx, f.code, v, link)
+		if err != nil {
+			return nil, err
+		}
+		*f.dst = s
+	}
+	if item.Url == "" {
+		return nil, nil
+	}
+
+	ints := []struct {
+		code *gojq.Code
+		dst  *int
+	}{
+		{e.item.width, &item.Width},
+		{e.item.height, &item.Height},
+		{e.item.duration, &item.Duration},
+	}
+	for _, f := range ints {
+		n, err := e.num(ctx, f.code, v, link)
+		if err != nil {
+			return nil, err
+		}
+		*f.dst = int(n)
+	}
+
+	size, err := e.num(ctx, e.item.contentLength, v, link)
+	if err != nil {
+		return nil, err
+	}
+	item.ContentLength = size
+
+	if e.item.typ != nil {
+		typ, err := e.str(ctx, e.item.typ, v, link)
+		if err != nil {
+			return nil, err
+		}
+		if typ != "" {
+			item.Type = models.MediaType(typ)
+		}
+		if !item.Type.Valid() {
+			return nil, fmt.Errorf("%w: unknown item type %q", extractor.ErrUpstreamChanged, typ)
+		}
+	}
+
+	return item, nil
+}
+
+// first returns the first value the expression yields for v, nil when it
+// yields none or is not defined.
+func (e *Extractor) first(ctx context.Context, code *gojq.Code, v any, link string) (any, error) {
+	if code == nil {
+		return nil, nil
+	}
+	out, ok := code.RunWithContext(ctx, v, link).Next()
+	if !ok {
+		return nil, nil
+	}
+	if err, isErr := out.(error); isErr {
+		return nil, err
+	}
+	return out, nil
+}
+
+// str evaluates an expression to a string; numbers are formatted.
+func (e *Extractor) str(ctx context.Context, code *gojq.Code, v any, link string) (string, error) {
+	out, err := e.first(ctx, code, v, link)
+	if err != nil {
+		return "", e.queryError("expression", err)
+	}
+	switch out := out.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return out, nil
+	case int:
+		return strconv.Itoa(out), nil
+	case float64:
+		return strconv.FormatFloat(out, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("%w: expected a string, got %T", extractor.ErrUpstreamChanged, out)
+	}
+}
+
+// num evaluates an expression to a number; numeric strings are parsed.
+func (e *Extractor) num(ctx context.Context, code *gojq.Code, v any, link string) (int64, error) {
+	out, err := e.first(ctx, code, v, link)
+	if err != nil {
+		return 0, e.queryError("expression", err)
+	}
+	switch out := out.(type) {
+	case nil:
+		return 0, nil
+	case int:
+		return int64(out), nil
+	case float64:
+		return int64(math.Round(out)), nil
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(out), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: expected a number, got %q", extractor.ErrUpstreamChanged, out)
+		}
+		return int64(math.Round(n)), nil
+	default:
+		return 0, fmt.Errorf("%w: expected a number, got %T", extractor.ErrUpstreamChanged, out)
+	}
+}
+
+// queryError reports a failed expression as a document the definition no
+// longer fits, unless the request itself ended.
+func (e *Extractor) queryError(name string, err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	return fmt.Errorf("%w: %s: %w", extractor.ErrUpstreamChanged, name, err)
+}
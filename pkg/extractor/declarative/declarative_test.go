@@ -0,0 +1,205 @@
+package declarative
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sxwebdev/downloaderbot/internal/models"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
+)
+
+const apiDoc = `{
+	"title": "A clip",
+	"description": "What happened today",
+	"thumbnail_url": "https://cdn.test/cover.jpg",
+	"files": {
+		"mp4": {"url": "https://cdn.test/720.mp4", "width": 1280, "height": 720, "duration": 12.6, "size": "1048576"},
+		"mp4-mobile": {"url": "https://cdn.test/360.mp4", "width": 640, "height": 360}
+	}
+}`
+
+const page = `<html><head><script id="__DATA__" type="application/json">{"post":{"caption":"hi","images":[
+	{"src":"https://cdn.test/1.jpg"},{"src":null},{"src":"https://cdn.test/2.jpg"}
+]}}</script></head></html>`
+
+func TestExtract(t *testing.T) {
+	var gotAccept string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/videos/abc":
+			gotAccept = r.Header.Get("Accept")
+			_, _ = w.Write([]byte(apiDoc))
+		case "/p/1":
+			_, _ = w.Write([]byte(page))
+		case "/p/2":
+			_, _ = w.Write([]byte(`<html>redesigned</html>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	t.Run("json endpoint", func(t *testing.T) {
+		e, err := New(Definition{
+			Name:      "clips",
+			Hosts:     []string{"clips.test"},
+			Path:      `^/(?P<id>[a-z]+)$`,
+			URL:       srv.URL + "/videos/{id}",
+			Headers:   map[string]string{"Accept": "application/json"},
+			Title:     ".title",
+			Caption:   ".description",
+			Thumbnail: ".thumbnail_url",
+			Items:     `.files | to_entries | sort_by(.key) | .[].value`,
+			Item: ItemDefinition{
+				Url:           ".url",
+				Quality:       `"\(.height)p"`,
+				Width:         ".width",
+				Height:        ".height",
+				Duration:      ".duration",
+				ContentLength: ".size",
+			},
+		})
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+
+		media, err := e.Extract(t.Context(), "https://clips.test/abc")
+		if err != nil {
+			t.Fatalf("Extract: %v", err)
+		}
+		if gotAccept != "application/json" {
+			t.Fatalf("headers not sent: Accept = %q", gotAccept)
+		}
+		if media.Source != "clips" || media.Title != "A clip" || media.Caption != "What happened today" || media.Url != "https://cdn.test/cover.jpg" {
+			t.Fatalf("unexpected media %+v", media)
+		}
+		if len(media.Items) != 2 {
+			t.Fatalf("got %d items, want 2", len(media.Items))
+		}
+		item := media.Items[0]
+		if item.Type != models.MediaTypeVideo || item.Url != "https://cdn.test/720.mp4" || item.Quality != "720p" ||
+			item.Width != 1280 || item.Height != 720 || item.Duration != 13 || item.ContentLength != 1048576 {
+			t.Fatalf("unexpected item %+v", item)
+		}
+		if media.Items[1].Id == item.Id {
+			t.Fatal("item ids are not unique")
+		}
+	})
+
+	embedded := Definition{
+		Name:     "posts",
+		Hosts:    []string{"posts.test"},
+		Embedded: `(?s)<script id="__DATA__"[^>]*>(.*?)</script>`,
+		Caption:  ".post.caption",
+		Items:    ".post.images[]",
+		Item:     ItemDefinition{Type: `"photo"`, Url: ".src"},
+	}
+
+	t.Run("embedded json", func(t *testing.T) {
+		e, err := New(embedded)
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		media, err := e.Extract(t.Context(), srv.URL+"/p/1")
+		if err != nil {
+			t.Fatalf("Extract: %v", err)
+		}
+		// The entry without a source is skipped.
+		if media.Caption != "hi" || len(media.Items) != 2 || media.Items[1].Url != "https://cdn.test/2.jpg" ||
+			media.Items[0].Type != models.MediaTypePhoto || media.Type != string(models.MediaTypePhoto) {
+			t.Fatalf("unexpected media %+v", media)
+		}
+	})
+
+	errs := []struct {
+		path string
+		want error
+	}{
+		{"/p/2", extractor.ErrUpstreamChanged},
+		{"/p/missing", extractor.ErrNotFound},
+	}
+	for _, tc := range errs {
+		t.Run("error "+tc.path, func(t *testing.T) {
+			e, err := New(embedded)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			if _, err := e.Extract(t.Context(), srv.URL+tc.path); !errors.Is(err, tc.want) {
+				t.Fatalf("want %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestExtract_Canceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	e, err := New(Definition{Name: "slow", Hosts: []string{"slow.test"}, Items: ".[]", Item: ItemDefinition{Url: ".url"}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if _, err := e.Extract(ctx, srv.URL); !errors.Is(err, context.Canceled) {
+		t.Fatalf("want Canceled, got %v", err)
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	valid := Definition{Name: "x", Hosts: []string{"x.test"}, Items: ".[]", Item: ItemDefinition{Url: ".url"}}
+
+	tests := map[string]func(d *Definition){
+		"no hosts":           func(d *Definition) { d.Hosts = nil },
+		"no item url":        func(d *Definition) { d.Item.Url = "" },
+		"bad path":           func(d *Definition) { d.Path = "(" },
+		"unknown group":      func(d *Definition) { d.Path = `^/(?P<id>\d+)$`; d.URL = "https://api.x.test/{slug}" },
+		"group without path": func(d *Definition) { d.URL = "https://api.x.test/{1}" },
+		"embedded no group":  func(d *Definition) { d.Embedded = "<script>" },
+		"bad jq":             func(d *Definition) { d.Title = ".title |" },
+		"unknown variable":   func(d *Definition) { d.Item.Url = "$link" },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			def := valid
+			mutate(&def)
+			if _, err := New(def); err == nil {
+				t.Fatal("want an error")
+			}
+		})
+	}
+
+	if _, err := New(valid); err != nil {
+		t.Fatalf("valid definition rejected: %v", err)
+	}
+}
+
+func TestRequestURL(t *testing.T) {
+	e, err := New(Definition{
+		Name:  "x",
+		Hosts: []string{"x.test"},
+		Path:  `^/(\w+)/(?P<id>\d+)$`,
+		URL:   "https://api.x.test/{1}/{id}?src={url}",
+		Items: ".[]",
+		Item:  ItemDefinition{Url: ".url"},
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	got, err := e.requestURL("https://x.test/clip/42")
+	if err != nil {
+		t.Fatalf("requestURL: %v", err)
+	}
+	if want := "https://api.x.test/clip/42?src=https%3A%2F%2Fx.test%2Fclip%2F42"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if _, err := e.requestURL("https://x.test/clip"); !errors.Is(err, extractor.ErrUnsupportedURL) {
+		t.Fatalf("want ErrUnsupportedURL, got %v", err)
+	}
+}
@@ -0,0 +1,170 @@
+// Package direct handles links that point straight at a media file
+// (https://cdn.example.com/clip.mp4), so the file goes through the normal
+// upload path instead of being rejected as an unknown source.
+package direct
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/sxwebdev/downloaderbot/internal/media"
+	"github.com/sxwebdev/downloaderbot/internal/models"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
+)
+
+func init() {
+	// Ahead of the generic page extractor in the catch-all chain: a media file
+	// is cheaper to recognize from a HEAD than a page from its body.
+	extractor.MustRegister(New(), extractor.AsCatchAll())
+}
+
+// Extractor implements the extractor.Extractor interface for direct links to
+// video and image files.
+type Extractor struct {
+	loader media.Loader
+}
+
+// New creates a new direct link extractor.
+func New() *Extractor {
+	return &Extractor{loader: media.Default()}
+}
+
+// Name returns the extractor name.
+func (e *Extractor) Name() string {
+	return string(models.MediaSourceDirect)
+}
+
+// Hosts returns no hosts: the extractor is registered as a catch-all.
+func (e *Extractor) Hosts() []string {
+	return nil
+}
+
+// Capabilities declares a direct link as a single publicly fetchable video or
+// photo.
+func (e *Extractor) Capabilities() extractor.Capabilities {
+	return extractor.Capabilities{
+		Inline:     true,
+		MediaTypes: []models.MediaType{models.MediaTypeVideo, models.MediaTypePhoto},
+		Output:     extractor.OutputItems,
+	}
+}
+
+// extensionTypes is the fallback for servers that don't report a usable
+// Content-Type.
+var extensionTypes = map[string]string{
+	".mp4":  "video/mp4",
+	".m4v":  "video/mp4",
+	".mov":  "video/quicktime",
+	".webm": "video/webm",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+	".webp": "image/webp",
+	".gif":  "image/gif",
+}
+
+// Extract asks the server what the link serves and turns it into a single
+// item. Anything but a video or an image is reported as
+// extractor.ErrNotSupported, so the link goes on to the generic extractor.
+func (e *Extractor) Extract(ctx context.Context, link string) (*models.Media, error) {
+	// The link comes from a user: the item is only fetched from public
+	// addresses, by the probe and by the download alike.
+	item := &models.MediaItem{Url: link, PublicOnly: true}
+
+	info, err := e.loader.Probe(ctx, item)
+	if err != nil {
+		return nil, probeError(ctx, err)
+	}
+
+	mimeType := mediaType(info.ContentType)
+	if !isMedia(mimeType) {
+		// Servers often label files as a generic binary, or not at all; only
+		// then does the extension decide.
+		if mimeType != "" && mimeType != "application/octet-stream" && mimeType != "binary/octet-stream" {
+			return nil, fmt.Errorf("%w: %s is not a media file", extractor.ErrNotSupported, mimeType)
+		}
+		mimeType = typeByExtension(link)
+		if mimeType == "" {
+			return nil, fmt.Errorf("%w: unknown file type", extractor.ErrNotSupported)
+		}
+	}
+
+	item.MimeType = mimeType
+	item.Type = models.MediaTypePhoto
+	if strings.HasPrefix(mimeType, "video/") {
+		item.Type = models.MediaTypeVideo
+	}
+	if info.ContentLength > 0 {
+		item.ContentLength = info.ContentLength
+	}
+
+	return &models.Media{
+		Source:     models.MediaSourceDirect,
+		RequestUrl: link,
+		Title:      fileName(link),
+		Type:       string(item.Type),
+		Url:        link,
+		Items:      []*models.MediaItem{item},
+	}, nil
+}
+
+// probeError reports a failed probe. A status with a meaning of its own ends
+// the chain with it; anything else leaves the link to the next extractor.
+func probeError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	var statusErr *media.StatusError
+	if errors.As(err, &statusErr) {
+		if kind := extractor.StatusError(statusErr.StatusCode); kind != nil {
+			return fmt.Errorf("failed to probe link: %w", kind)
+		}
+	}
+	return fmt.Errorf("%w: failed to probe link: %w", extractor.ErrNotSupported, err)
+}
+
+func mediaType(contentType string) string {
+	if contentType == "" {
+		return ""
+	}
+	t, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return t
+}
+
+// isMedia reports whether the MIME type can be sent as a video or a photo.
+// Streaming playlists (HLS) are labeled video but are not files, and Telegram
+// doesn't take vector images as photos.
+func isMedia(mimeType string) bool {
+	if strings.Contains(mimeType, "mpegurl") || mimeType == "image/svg+xml" {
+		return false
+	}
+	return strings.HasPrefix(mimeType, "video/") || strings.HasPrefix(mimeType, "image/")
+}
+
+func typeByExtension(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return extensionTypes[strings.ToLower(path.Ext(u.Path))]
+}
+
+func fileName(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	name := path.Base(u.Path)
+	if name == "/" || name == "." {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,116 @@
+package direct
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sxwebdev/downloaderbot/internal/media"
+	"github.com/sxwebdev/downloaderbot/internal/models"
+	"github.com/sxwebdev/downloaderbot/internal/util"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
+)
+
+func TestExtract(t *testing.T) {
+	types := map[string]string{
+		"/clip.mp4":      "video/mp4",
+		"/photo":         "image/jpeg; charset=binary",
+		"/untyped.webm":  "application/octet-stream",
+		"/labeled.mp4":   "text/html; charset=utf-8",
+		"/blob":          "application/octet-stream",
+		"/playlist.m3u8": "application/vnd.apple.mpegurl",
+	}
+	statuses := map[string]int{
+		"/private.mp4": http.StatusForbidden,
+		"/broken.mp4":  http.StatusBadGateway,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status, ok := statuses[r.URL.Path]; ok {
+			w.WriteHeader(status)
+			return
+		}
+		contentType, ok := types[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Length", "2048")
+	}))
+	defer srv.Close()
+
+	e := &Extractor{loader: media.NewHTTPLoader(media.WithHTTPClient(srv.Client()))}
+	ctx := context.Background()
+
+	tests := []struct {
+		path     string
+		wantType models.MediaType
+		wantMime string
+	}{
+		{"/clip.mp4", models.MediaTypeVideo, "video/mp4"},
+		{"/photo", models.MediaTypePhoto, "image/jpeg"},
+		// The extension only decides when the server labels the file as binary.
+		{"/untyped.webm", models.MediaTypeVideo, "video/webm"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			media, err := e.Extract(ctx, srv.URL+tc.path)
+			if err != nil {
+				t.Fatalf("Extract: %v", err)
+			}
+			if media.Source != models.MediaSourceDirect || len(media.Items) != 1 {
+				t.Fatalf("unexpected media %+v", media)
+			}
+			item := media.Items[0]
+			if item.Type != tc.wantType || item.MimeType != tc.wantMime || item.ContentLength != 2048 || item.Url != srv.URL+tc.path {
+				t.Fatalf("unexpected item %+v", item)
+			}
+		})
+	}
+
+	for _, path := range []string{"/labeled.mp4", "/blob", "/playlist.m3u8", "/broken.mp4"} {
+		t.Run("not supported "+path, func(t *testing.T) {
+			_, err := e.Extract(ctx, srv.URL+path)
+			if !errors.Is(err, extractor.ErrNotSupported) {
+				t.Fatalf("want ErrNotSupported, got %v", err)
+			}
+		})
+	}
+
+	// Statuses with a meaning of their own are reported as such, not handed
+	// on to the next extractor.
+	for path, want := range map[string]error{
+		"/missing.mp4": extractor.ErrNotFound,
+		"/private.mp4": extractor.ErrBlocked,
+	} {
+		t.Run("status "+path, func(t *testing.T) {
+			_, err := e.Extract(ctx, srv.URL+path)
+			if !errors.Is(err, want) || errors.Is(err, extractor.ErrNotSupported) {
+				t.Fatalf("want %v, got %v", want, err)
+			}
+		})
+	}
+
+	t.Run("canceled", func(t *testing.T) {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := e.Extract(canceled, srv.URL+"/clip.mp4")
+		if err != context.Canceled {
+			t.Fatalf("want the context error as is, got %v", err)
+		}
+	})
+}
+
+func TestExtract_PrivateAddress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request %s reached the server", r.URL)
+	}))
+	defer srv.Close()
+
+	_, err := New().Extract(context.Background(), srv.URL+"/clip.mp4")
+	if !errors.Is(err, util.ErrPrivateAddress) {
+		t.Fatalf("want ErrPrivateAddress, got %v", err)
+	}
+}
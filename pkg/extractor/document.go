@@ -0,0 +1,108 @@
+package extractor
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/sxwebdev/downloaderbot/internal/models"
+)
+
+// Document is the description of media that extractors living outside the Go
+// code (plugins, scripts) produce, as JSON or as a script object. The format
+// is documented in pkg/extractor/plugin.
+type Document struct {
+	Title     string          `json:"title"`
+	Caption   string          `json:"caption"`
+	Thumbnail string          `json:"thumbnail"`
+	Items     []*DocumentItem `json:"items"`
+}
+
+// DocumentItem is one entry of a Document.
+type DocumentItem struct {
+	Type              models.MediaType  `json:"type"`
+	Url               string            `json:"url"`
+	Quality           string            `json:"quality"`
+	MimeType          string            `json:"mime_type"`
+	Width             int               `json:"width"`
+	Height            int               `json:"height"`
+	Duration          int               `json:"duration"`
+	ContentLength     int64             `json:"content_length"`
+	Thumbnail         string            `json:"thumbnail"`
+	VideoWithoutAudio bool              `json:"video_without_audio"`
+	Headers           map[string]string `json:"headers"`
+	Variants          []*models.Variant `json:"variants"`
+}
+
+// Media converts the document, checking the fields the bot relies on.
+func (d *Document) Media(source models.MediaSource, requestURL string) (*models.Media, error) {
+	if len(d.Items) == 0 {
+		return nil, errors.New("no items")
+	}
+
+	media := &models.Media{
+		Source:     source,
+		RequestUrl: requestURL,
+		Title:      d.Title,
+		Caption:    d.Caption,
+		Url:        d.Thumbnail,
+		Items:      make([]*models.MediaItem, len(d.Items)),
+	}
+
+	for i, item := range d.Items {
+		if item == nil {
+			return nil, fmt.Errorf("item %d is null", i)
+		}
+		if !item.Type.Valid() {
+			return nil, fmt.Errorf("item %d has unknown type %q", i, item.Type)
+		}
+		if item.Url == "" {
+			return nil, fmt.Errorf("item %d has no url", i)
+		}
+		for j, v := range item.Variants {
+			if v == nil || v.Url == "" {
+				return nil, fmt.Errorf("variant %d of item %d has no url", j, i)
+			}
+		}
+
+		media.Items[i] = &models.MediaItem{
+			Id:                strconv.Itoa(i),
+			Type:              item.Type,
+			Url:               item.Url,
+			Quality:           item.Quality,
+			MimeType:          item.MimeType,
+			Width:             item.Width,
+			Height:            item.Height,
+			Duration:          item.Duration,
+			ContentLength:     item.ContentLength,
+			ThumbnailUrl:      item.Thumbnail,
+			VideoWithoutAudio: item.VideoWithoutAudio,
+			DownloadHeaders:   item.Headers,
+			Variants:          item.Variants,
+		}
+	}
+
+	media.Type = string(media.Items[0].Type)
+
+	return media, nil
+}
+
+// kindNames are the names extractors outside the Go code report the taxonomy
+// errors by.
+var kindNames = map[string]error{
+	"not_supported":    ErrNotSupported,
+	"unsupported_url":  ErrUnsupportedURL,
+	"blocked":          ErrBlocked,
+	"private":          ErrPrivate,
+	"not_found":        ErrNotFound,
+	"login_required":   ErrLoginRequired,
+	"rate_limited":     ErrRateLimited,
+	"geo_blocked":      ErrGeoBlocked,
+	"upstream_changed": ErrUpstreamChanged,
+}
+
+// KindByName returns the taxonomy error called name ("not_found",
+// "rate_limited", ...), nil for an unknown name.
+func KindByName(name string) error {
+	return kindNames[name]
+}
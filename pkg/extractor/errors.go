@@ -0,0 +1,105 @@
+package extractor
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Extraction errors. Extractors wrap one of them (with %w) so that callers can
+// tell why a link failed without parsing messages: the Telegram handler shows
+// a matching message, the gRPC API picks a status code and metrics a reason
+// label. Errors wrapping none of them are treated as unexpected failures.
+var (
+	// ErrNotSupported means the extractor can't handle this particular link,
+	// although it is registered for the host.
+	ErrNotSupported = errors.New("not supported by extractor")
+
+	// ErrBlocked means the source refused to serve the extractor, e.g. an
+	// anti-bot challenge or a login wall instead of the media.
+	ErrBlocked = errors.New("blocked by source")
+
+	// ErrUnsupportedURL means the host is supported but this kind of link on it
+	// is not (a profile or a stories page instead of a post, say). The registry
+	// returns it before any extraction work.
+	ErrUnsupportedURL = errors.New("this kind of link is not supported")
+
+	// ErrPrivate means the media exists but only some accounts may see it.
+	ErrPrivate = errors.New("content is private")
+
+	// ErrNotFound means the media doesn't exist (anymore): a deleted post, a
+	// mistyped link.
+	ErrNotFound = errors.New("content not found")
+
+	// ErrLoginRequired means the source shows the media to signed-in users
+	// only, e.g. age-restricted videos.
+	ErrLoginRequired = errors.New("login required")
+
+	// ErrRateLimited means the source throttles the bot; the same request is
+	// likely to work later.
+	ErrRateLimited = errors.New("rate limited by source")
+
+	// ErrGeoBlocked means the media is not available in the bot's region.
+	ErrGeoBlocked = errors.New("not available in this region")
+
+	// ErrUpstreamChanged means the source answered in a shape the extractor
+	// doesn't understand, which usually takes a code change to fix.
+	ErrUpstreamChanged = errors.New("source response format changed")
+)
+
+// kinds lists the taxonomy in the order Kind checks it.
+var kinds = []error{
+	ErrUnsupportedURL,
+	ErrPrivate,
+	ErrNotFound,
+	ErrLoginRequired,
+	ErrRateLimited,
+	ErrGeoBlocked,
+	ErrUpstreamChanged,
+	ErrBlocked,
+	ErrNotSupported,
+}
+
+// Kind returns the taxonomy error err wraps, or nil when it wraps none.
+func Kind(err error) error {
+	for _, kind := range kinds {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+	return nil
+}
+
+// ShouldFallThrough reports whether err lets the next extractor registered for
+// the host try the link. Errors about the extractor's own access or parsing
+// (blocked, throttled, an unexpected response) may not hit another one; errors
+// about the media itself (a private post, a deleted one) or a timeout would
+// fail the same way with any extractor, so the chain stops there.
+func ShouldFallThrough(err error) bool {
+	return errors.Is(err, ErrNotSupported) ||
+		errors.Is(err, ErrBlocked) ||
+		errors.Is(err, ErrRateLimited) ||
+		errors.Is(err, ErrUpstreamChanged)
+}
+
+// StatusError maps an unsuccessful HTTP status of a source to the taxonomy. It
+// returns nil for statuses without a meaning of their own (5xx and the like),
+// which callers report as they see fit.
+func StatusError(status int) error {
+	var kind error
+	switch status {
+	case http.StatusNotFound, http.StatusGone:
+		kind = ErrNotFound
+	case http.StatusUnauthorized:
+		kind = ErrLoginRequired
+	case http.StatusForbidden:
+		kind = ErrBlocked
+	case http.StatusTooManyRequests:
+		kind = ErrRateLimited
+	case http.StatusUnavailableForLegalReasons:
+		kind = ErrGeoBlocked
+	default:
+		return nil
+	}
+	return fmt.Errorf("%w: status %d", kind, status)
+}
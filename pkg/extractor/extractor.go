@@ -2,6 +2,10 @@ package extractor
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
 
 	"github.com/sxwebdev/downloaderbot/internal/models"
 )
@@ -11,9 +15,126 @@ type Extractor interface {
 	// Name returns the unique name of the extractor
 	Name() string
 
-	// Hosts returns the list of hosts that this extractor supports
+	// Hosts returns the list of hosts that this extractor supports. A host may
+	// be a wildcard: "*.example.com" or "example.*"
 	Hosts() []string
 
 	// Extract extracts media from the given URL
 	Extract(ctx context.Context, url string) (*models.Media, error)
 }
+
+// Pattern is a URL shape an extractor handles. A URL matches when its host fits
+// Host (one of the extractor's Hosts, wildcards included; empty means any of
+// them) and Path matches its path or Match accepts it. Set one of Path and
+// Match.
+type Pattern struct {
+	Host  string
+	Path  *regexp.Regexp
+	Match func(u *url.URL) bool
+}
+
+// PatternProvider is the optional interface an Extractor implements when it
+// only handles some URL shapes on its hosts (posts but not profiles, say).
+// The registry then rejects other links with ErrUnsupportedURL before any
+// network or browser work. Extractors that don't implement it accept every URL
+// of their hosts.
+type PatternProvider interface {
+	Patterns() []Pattern
+}
+
+// matches reports whether u (with its host already normalized) fits the
+// pattern.
+func (p Pattern) matches(host string, u *url.URL) bool {
+	if p.Host != "" && !matchHost(p.Host, host) {
+		return false
+	}
+	if p.Path != nil {
+		return p.Path.MatchString(u.EscapedPath())
+	}
+	return p.Match(u)
+}
+
+// OutputKind tells consumers what the items of an extracted Media stand for.
+type OutputKind string
+
+const (
+	// OutputItems means every item is a ready-to-send file (a photo, a video, a
+	// carousel slide) and the whole list is delivered to the user.
+	OutputItems OutputKind = "items"
+	// OutputFormats means the formats of the entries, their Variants
+	// (qualities, audio-only tracks), are offered as a list of download links
+	// rather than uploaded.
+	OutputFormats OutputKind = "formats"
+)
+
+// Capabilities declares how the media produced by an extractor can be handled,
+// so the registry, the Telegram handler and the gRPC API decide from the
+// declaration instead of special-casing sources by name.
+type Capabilities struct {
+	// Inline reports whether the source can be offered in Telegram inline mode.
+	Inline bool
+	// DownloadHeaders reports that media URLs are only fetchable with the
+	// item's DownloadHeaders (e.g. TikTok CDN), so they can't be handed to
+	// Telegram or API clients as plain links.
+	DownloadHeaders bool
+	// MediaTypes lists the media types the source produces.
+	MediaTypes []models.MediaType
+	// Output tells whether the items are ready to send or a format list.
+	Output OutputKind
+}
+
+// CapabilitiesProvider is the optional interface an Extractor implements to
+// declare its capabilities. Extractors that don't implement it are treated as
+// DefaultCapabilities.
+type CapabilitiesProvider interface {
+	Capabilities() Capabilities
+}
+
+// DefaultCapabilities describes a plain extractor: publicly fetchable photos and
+// videos that are delivered as they are, inline included.
+func DefaultCapabilities() Capabilities {
+	return Capabilities{
+		Inline:     true,
+		MediaTypes: []models.MediaType{models.MediaTypeVideo, models.MediaTypePhoto},
+		Output:     OutputItems,
+	}
+}
+
+// CapabilitiesOf returns the capabilities declared by ext, falling back to
+// DefaultCapabilities.
+func CapabilitiesOf(ext Extractor) Capabilities {
+	if p, ok := ext.(CapabilitiesProvider); ok {
+		return p.Capabilities()
+	}
+	return DefaultCapabilities()
+}
+
+// Produces reports whether the source declares the given media type.
+func (c Capabilities) Produces(t models.MediaType) bool {
+	return slices.Contains(c.MediaTypes, t)
+}
+
+// Validate checks that the declaration is complete and uses known values.
+func (c Capabilities) Validate() error {
+	switch c.Output {
+	case OutputItems, OutputFormats:
+	default:
+		return fmt.Errorf("unknown output kind %q", c.Output)
+	}
+
+	if len(c.MediaTypes) == 0 {
+		return fmt.Errorf("no media types declared")
+	}
+	for _, t := range c.MediaTypes {
+		if !t.Valid() {
+			return fmt.Errorf("unknown media type %q", t)
+		}
+	}
+
+	// Telegram fetches inline results from the URL itself, without any headers.
+	if c.Inline && c.DownloadHeaders {
+		return fmt.Errorf("inline mode can't be declared for a source that needs download headers")
+	}
+
+	return nil
+}
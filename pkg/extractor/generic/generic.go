@@ -0,0 +1,140 @@
+// Package generic provides the catch-all extractor for hosts no other extractor
+// handles. It reads the media a page publishes for link previews: OpenGraph
+// (og:video, og:image), Twitter cards (twitter:player:stream) and JSON-LD
+// VideoObject metadata, which many news sites, blogs and small video hosts
+// carry.
+package generic
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/sxwebdev/downloaderbot/internal/models"
+	"github.com/sxwebdev/downloaderbot/internal/util"
+	"github.com/sxwebdev/downloaderbot/pkg/browser"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
+)
+
+// maxPageSize bounds how much of a page is read; the metadata lives in <head>.
+const maxPageSize = 2 << 20
+
+func init() {
+	// Last in the catch-all chain: reading a page is the most expensive guess.
+	extractor.MustRegister(New(), extractor.AsCatchAll(), extractor.WithPriority(extractor.PriorityFallback))
+}
+
+// Extractor implements the extractor.Extractor interface for any web page
+// that publishes preview metadata.
+type Extractor struct {
+	client *http.Client
+}
+
+// New creates a new generic extractor. Its client only connects to public
+// addresses: the links come from users and may point at the bot's network.
+func New() *Extractor {
+	return &Extractor{client: util.PublicHttpClient()}
+}
+
+// Name returns the extractor name, which is also the source reported in
+// metrics for every link it serves.
+func (e *Extractor) Name() string {
+	return string(models.MediaSourceGeneric)
+}
+
+// Hosts returns no hosts: the extractor is registered as the catch-all.
+func (e *Extractor) Hosts() []string {
+	return nil
+}
+
+// Capabilities declares the generic source as a single publicly fetchable
+// video or photo.
+func (e *Extractor) Capabilities() extractor.Capabilities {
+	return extractor.Capabilities{
+		Inline:     true,
+		MediaTypes: []models.MediaType{models.MediaTypeVideo, models.MediaTypePhoto},
+		Output:     extractor.OutputItems,
+	}
+}
+
+// Extract fetches the page and returns the video it publishes, or its preview
+// image when there is none. Pages without either are reported as
+// extractor.ErrNotSupported.
+func (e *Extractor) Extract(ctx context.Context, url string) (*models.Media, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", browser.UserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		if kind := extractor.StatusError(resp.StatusCode); kind != nil {
+			return nil, fmt.Errorf("failed to fetch page: %w", kind)
+		}
+		return nil, fmt.Errorf("failed to fetch page: status %d", resp.StatusCode)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "" && !strings.Contains(mediaType, "html") {
+		return nil, fmt.Errorf("%w: not a web page (%s)", extractor.ErrNotSupported, mediaType)
+	}
+
+	// Relative URLs resolve against the page the redirects ended at.
+	meta, err := parsePage(io.LimitReader(resp.Body, maxPageSize), resp.Request.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse page: %w", err)
+	}
+
+	item := itemFromMeta(meta)
+	if item == nil {
+		return nil, fmt.Errorf("%w: no media found on the page", extractor.ErrNotSupported)
+	}
+	// The page names the media URL, so it is no more trusted than the link.
+	item.PublicOnly = true
+
+	return &models.Media{
+		Source:     models.MediaSourceGeneric,
+		RequestUrl: url,
+		Title:      meta.Title,
+		Caption:    meta.Description,
+		Type:       string(item.Type),
+		Url:        item.Url,
+		Items:      []*models.MediaItem{item},
+	}, nil
+}
+
+// itemFromMeta picks the video when the page has one and the preview image
+// otherwise.
+func itemFromMeta(meta *pageMeta) *models.MediaItem {
+	if meta.VideoURL != "" {
+		return &models.MediaItem{
+			Type:         models.MediaTypeVideo,
+			Url:          meta.VideoURL,
+			MimeType:     meta.VideoType,
+			Width:        meta.Width,
+			Height:       meta.Height,
+			Duration:     meta.Duration,
+			ThumbnailUrl: meta.Thumbnail,
+		}
+	}
+
+	if meta.ImageURL != "" {
+		return &models.MediaItem{
+			Type:     models.MediaTypePhoto,
+			Url:      meta.ImageURL,
+			MimeType: meta.ImageType,
+			Width:    meta.ImageWidth,
+			Height:   meta.ImageHeight,
+		}
+	}
+
+	return nil
+}
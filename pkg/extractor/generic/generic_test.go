@@ -0,0 +1,166 @@
+package generic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sxwebdev/downloaderbot/internal/models"
+	"github.com/sxwebdev/downloaderbot/internal/util"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
+)
+
+const ogPage = `<html><head>
+<title>Fallback title</title>
+<meta property="og:title" content="A clip">
+<meta property="og:description" content="What happened today">
+<meta property="og:image" content="/thumb.jpg">
+<meta property="og:video" content="https://player.example.test/embed/1">
+<meta property="og:video:type" content="text/html">
+<meta property="og:video" content="/clip.mp4">
+<meta name="twitter:player:stream" content="https://cdn.example.test/stream.mp4">
+<meta name="twitter:player:stream:content_type" content="video/mp4">
+<meta name="twitter:player:width" content="1280">
+<meta name="twitter:player:height" content="720">
+</head></html>`
+
+const jsonLDPage = `<html><head>
+<meta property="og:title" content="OG title">
+<meta property="og:image" content="https://cdn.example.test/og.jpg">
+<script type="application/ld+json">{"@context":"https://schema.org","@graph":[
+	{"@type":"WebPage","name":"Page"},
+	{"@type":"VideoObject","name":"LD title","description":"LD description",
+	 "contentUrl":"https://cdn.example.test/video.mp4","encodingFormat":"mp4",
+	 "thumbnailUrl":["https://cdn.example.test/ld.jpg"],"duration":"PT1M30S",
+	 "width":{"@type":"QuantitativeValue","value":640},"height":"360"}
+]}</script>
+</head></html>`
+
+const imagePage = `<html><head>
+<meta property="og:title" content="A photo">
+<meta property="og:image" content="https://cdn.example.test/photo.jpg">
+<meta property="og:image:width" content="1080">
+<meta property="og:image:height" content="1350">
+</head></html>`
+
+func TestExtract(t *testing.T) {
+	pages := map[string]string{
+		"/og":    ogPage,
+		"/ld":    jsonLDPage,
+		"/image": imagePage,
+		"/empty": `<html><head><title>Nothing</title></head></html>`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/file.mp4" {
+			w.Header().Set("Content-Type", "video/mp4")
+			return
+		}
+		page, ok := pages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(page))
+	}))
+	defer srv.Close()
+
+	e := &Extractor{client: srv.Client()}
+	ctx := context.Background()
+
+	t.Run("opengraph skips embedded players", func(t *testing.T) {
+		media, err := e.Extract(ctx, srv.URL+"/og")
+		if err != nil {
+			t.Fatalf("Extract: %v", err)
+		}
+		if media.Source != models.MediaSourceGeneric || media.Title != "A clip" || media.Caption != "What happened today" {
+			t.Fatalf("unexpected media %+v", media)
+		}
+		item := media.Items[0]
+		if item.Type != models.MediaTypeVideo || item.Url != srv.URL+"/clip.mp4" || item.ThumbnailUrl != srv.URL+"/thumb.jpg" {
+			t.Fatalf("unexpected item %+v", item)
+		}
+	})
+
+	t.Run("json-ld video object wins", func(t *testing.T) {
+		media, err := e.Extract(ctx, srv.URL+"/ld")
+		if err != nil {
+			t.Fatalf("Extract: %v", err)
+		}
+		item := media.Items[0]
+		want := models.MediaItem{
+			Type:         models.MediaTypeVideo,
+			Url:          "https://cdn.example.test/video.mp4",
+			Width:        640,
+			Height:       360,
+			Duration:     90,
+			ThumbnailUrl: "https://cdn.example.test/ld.jpg",
+		}
+		if item.Type != want.Type || item.Url != want.Url || item.Width != want.Width || item.Height != want.Height ||
+			item.Duration != want.Duration || item.ThumbnailUrl != want.ThumbnailUrl || item.MimeType != "" {
+			t.Fatalf("got %+v, want %+v", item, want)
+		}
+		if media.Title != "LD title" || media.Caption != "LD description" {
+			t.Fatalf("unexpected media %+v", media)
+		}
+	})
+
+	t.Run("preview image without video", func(t *testing.T) {
+		media, err := e.Extract(ctx, srv.URL+"/image")
+		if err != nil {
+			t.Fatalf("Extract: %v", err)
+		}
+		item := media.Items[0]
+		if item.Type != models.MediaTypePhoto || item.Width != 1080 || item.Height != 1350 {
+			t.Fatalf("unexpected item %+v", item)
+		}
+	})
+
+	t.Run("missing page", func(t *testing.T) {
+		_, err := e.Extract(ctx, srv.URL+"/missing")
+		if !errors.Is(err, extractor.ErrNotFound) {
+			t.Fatalf("want ErrNotFound, got %v", err)
+		}
+	})
+
+	for _, path := range []string{"/empty", "/file.mp4"} {
+		t.Run("not supported "+path, func(t *testing.T) {
+			_, err := e.Extract(ctx, srv.URL+path)
+			if !errors.Is(err, extractor.ErrNotSupported) {
+				t.Fatalf("want ErrNotSupported, got %v", err)
+			}
+		})
+	}
+}
+
+func TestExtract_PrivateAddress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request %s reached the server", r.URL)
+	}))
+	defer srv.Close()
+
+	_, err := New().Extract(context.Background(), srv.URL+"/og")
+	if !errors.Is(err, util.ErrPrivateAddress) {
+		t.Fatalf("want ErrPrivateAddress, got %v", err)
+	}
+}
+
+func TestParseISODuration(t *testing.T) {
+	tests := map[string]int{
+		"PT1M30S":    90,
+		"PT2H":       7200,
+		"P1DT1S":     86401,
+		"PT12.5S":    12,
+		"pt45s":      45,
+		"90":         0,
+		"":           0,
+		"PT1H2M3.9S": 3723,
+	}
+	for in, want := range tests {
+		if got := parseISODuration(in); got != want {
+			t.Errorf("parseISODuration(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
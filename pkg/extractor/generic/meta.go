@@ -0,0 +1,297 @@
+package generic
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// pageMeta is the media metadata a page publishes through OpenGraph, Twitter
+// cards and JSON-LD, with URLs already resolved against the page.
+type pageMeta struct {
+	Title       string
+	Description string
+	Thumbnail   string
+
+	VideoURL  string
+	VideoType string
+	Width     int
+	Height    int
+	Duration  int
+
+	ImageURL    string
+	ImageType   string
+	ImageWidth  int
+	ImageHeight int
+}
+
+// parsePage reads the metadata of an HTML page. A VideoObject in JSON-LD wins
+// over OpenGraph, which wins over the Twitter player card; embedded players
+// (og:video of type text/html and the like) are ignored since only a file can
+// be sent.
+func parsePage(r io.Reader, base *url.URL) (*pageMeta, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[string]string)
+	var videos []map[string]string
+	doc.Find("meta").Each(func(_ int, s *goquery.Selection) {
+		key, ok := s.Attr("property")
+		if !ok {
+			key, _ = s.Attr("name")
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		content, _ := s.Attr("content")
+		content = strings.TrimSpace(content)
+		if key == "" || content == "" {
+			return
+		}
+
+		// OpenGraph arrays: every og:video starts a new video, which the
+		// og:video:* tags after it describe.
+		if key == "og:video" || key == "og:video:url" {
+			videos = append(videos, map[string]string{"url": content})
+		} else if prop, ok := strings.CutPrefix(key, "og:video:"); ok && len(videos) > 0 {
+			videos[len(videos)-1][prop] = content
+		}
+
+		// The first occurrence is the main one when a tag is repeated.
+		if tags[key] == "" {
+			tags[key] = content
+		}
+	})
+
+	resolve := func(raw string) string {
+		if raw == "" {
+			return ""
+		}
+		u, err := base.Parse(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return ""
+		}
+		return u.String()
+	}
+
+	meta := &pageMeta{
+		Title:       first(tags["og:title"], tags["twitter:title"], strings.TrimSpace(doc.Find("title").First().Text())),
+		Description: first(tags["og:description"], tags["twitter:description"], tags["description"]),
+		Duration:    atoi(tags["video:duration"]),
+	}
+
+	image := resolve(first(tags["og:image:secure_url"], tags["og:image:url"], tags["og:image"], tags["twitter:image"], tags["twitter:image:src"]))
+	meta.Thumbnail = image
+	if image != "" {
+		meta.ImageURL = image
+		meta.ImageType = tags["og:image:type"]
+		meta.ImageWidth = atoi(tags["og:image:width"])
+		meta.ImageHeight = atoi(tags["og:image:height"])
+	}
+
+	var ogVideo map[string]string
+	for _, v := range videos {
+		v["url"] = resolve(first(v["secure_url"], v["url"]))
+		if isFile(v["url"], v["type"]) {
+			ogVideo = v
+			break
+		}
+	}
+	stream := resolve(tags["twitter:player:stream"])
+
+	switch {
+	case parseJSONLD(doc, meta, resolve):
+	case ogVideo != nil:
+		meta.VideoURL = ogVideo["url"]
+		meta.VideoType = ogVideo["type"]
+		meta.Width = atoi(ogVideo["width"])
+		meta.Height = atoi(ogVideo["height"])
+		if d := atoi(ogVideo["duration"]); d > 0 {
+			meta.Duration = d
+		}
+	case isFile(stream, tags["twitter:player:stream:content_type"]):
+		meta.VideoURL = stream
+		meta.VideoType = tags["twitter:player:stream:content_type"]
+		meta.Width = atoi(tags["twitter:player:width"])
+		meta.Height = atoi(tags["twitter:player:height"])
+	}
+
+	return meta, nil
+}
+
+// videoObject is the part of a schema.org VideoObject used here.
+type videoObject struct {
+	Type         any             `json:"@type"`
+	Graph        json.RawMessage `json:"@graph"`
+	Name         string          `json:"name"`
+	Description  string          `json:"description"`
+	ContentURL   string          `json:"contentUrl"`
+	EncodingType string          `json:"encodingFormat"`
+	ThumbnailURL any             `json:"thumbnailUrl"`
+	Duration     string          `json:"duration"`
+	Width        any             `json:"width"`
+	Height       any             `json:"height"`
+}
+
+// parseJSONLD fills meta from the first VideoObject with a downloadable
+// contentUrl and reports whether it found one.
+func parseJSONLD(doc *goquery.Document, meta *pageMeta, resolve func(string) string) bool {
+	var found *videoObject
+	doc.Find(`script[type="application/ld+json"]`).EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		found = findVideoObject(json.RawMessage(s.Text()))
+		return found == nil
+	})
+	if found == nil {
+		return false
+	}
+
+	meta.VideoURL = resolve(found.ContentURL)
+	// encodingFormat is a MIME type or just an extension ("mp4").
+	if strings.Contains(found.EncodingType, "/") {
+		meta.VideoType = found.EncodingType
+	}
+	meta.Title = first(found.Name, meta.Title)
+	meta.Description = first(found.Description, meta.Description)
+	meta.Width = anyInt(found.Width)
+	meta.Height = anyInt(found.Height)
+	if d := parseISODuration(found.Duration); d > 0 {
+		meta.Duration = d
+	}
+	if thumb := resolve(anyString(found.ThumbnailURL)); thumb != "" {
+		meta.Thumbnail = thumb
+	}
+
+	return true
+}
+
+// findVideoObject looks for a VideoObject in a JSON-LD document, which may be
+// a single object, an array of them or an object with an @graph.
+func findVideoObject(raw json.RawMessage) *videoObject {
+	var list []json.RawMessage
+	if err := json.Unmarshal(raw, &list); err == nil {
+		for _, item := range list {
+			if v := findVideoObject(item); v != nil {
+				return v
+			}
+		}
+		return nil
+	}
+
+	var obj videoObject
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil
+	}
+	if len(obj.Graph) > 0 {
+		return findVideoObject(obj.Graph)
+	}
+	if !hasType(obj.Type, "VideoObject") || !isFile(obj.ContentURL, obj.EncodingType) {
+		return nil
+	}
+
+	return &obj
+}
+
+func hasType(t any, want string) bool {
+	switch v := t.(type) {
+	case string:
+		return v == want
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var videoExtensions = []string{".mp4", ".m4v", ".mov", ".webm"}
+
+// isFile reports whether a video URL points at a file that can be sent, as
+// opposed to an embedded player page or a streaming playlist.
+func isFile(rawURL, contentType string) bool {
+	if rawURL == "" {
+		return false
+	}
+	if strings.Contains(contentType, "/") {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err == nil {
+			return strings.HasPrefix(mediaType, "video/") && !strings.Contains(mediaType, "mpegurl")
+		}
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	ext := strings.ToLower(path.Ext(u.Path))
+	for _, e := range videoExtensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+var reISODuration = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// parseISODuration converts an ISO 8601 duration (PT1M30S) into whole seconds.
+func parseISODuration(s string) int {
+	m := reISODuration.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(s)))
+	if m == nil {
+		return 0
+	}
+	seconds, _ := strconv.ParseFloat(m[4], 64)
+	return atoi(m[1])*86400 + atoi(m[2])*3600 + atoi(m[3])*60 + int(seconds)
+}
+
+func first(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(strings.TrimSpace(s))
+	return n
+}
+
+// anyInt reads a JSON-LD number, which sites publish as a number, a string or
+// a QuantitativeValue.
+func anyInt(v any) int {
+	switch n := v.(type) {
+	case float64:
+		return int(n)
+	case string:
+		return atoi(strings.TrimSuffix(n, "px"))
+	case map[string]any:
+		return anyInt(n["value"])
+	}
+	return 0
+}
+
+// anyString reads a JSON-LD URL, which may be a string, a list of them or an
+// ImageObject.
+func anyString(v any) string {
+	switch s := v.(type) {
+	case string:
+		return s
+	case []any:
+		if len(s) > 0 {
+			return anyString(s[0])
+		}
+	case map[string]any:
+		return first(anyString(s["contentUrl"]), anyString(s["url"]))
+	}
+	return ""
+}
@@ -0,0 +1,110 @@
+package extractor
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// hostKind tells how a registered host is matched against a link's host.
+type hostKind int
+
+const (
+	// hostExact matches the host itself, e.g. "tumblr.com".
+	hostExact hostKind = iota
+	// hostSuffix matches every subdomain of the base, e.g. "*.tumblr.com"
+	// matches "someblog.tumblr.com" but not "tumblr.com" itself.
+	hostSuffix
+	// hostTLD matches the base under any top-level or country domain, e.g.
+	// "instagram.*" matches "instagram.com.br" and "instagram.de" but not
+	// "instagram.com.attacker.net" (see isTLD).
+	hostTLD
+)
+
+// hostPattern is a parsed host as returned by Extractor.Hosts.
+type hostPattern struct {
+	raw  string
+	kind hostKind
+	base string
+}
+
+// parseHostPattern normalizes and parses a registered host. Wildcards are only
+// allowed as the whole leftmost label ("*.example.com") or the whole rightmost
+// part ("example.*"), and a suffix wildcard needs at least two fixed labels so
+// it can't claim a whole top-level domain.
+func parseHostPattern(host string) (hostPattern, error) {
+	raw := normalizeHost(host)
+	p := hostPattern{raw: raw, kind: hostExact, base: raw}
+
+	switch {
+	case strings.HasPrefix(raw, "*."):
+		p.kind, p.base = hostSuffix, strings.TrimPrefix(raw, "*.")
+		if strings.Count(p.base, ".") < 1 {
+			return hostPattern{}, fmt.Errorf("wildcard host %s is too broad", host)
+		}
+	case strings.HasSuffix(raw, ".*"):
+		p.kind, p.base = hostTLD, strings.TrimSuffix(raw, ".*")
+	}
+
+	if p.base == "" || strings.Contains(p.base, "*") ||
+		strings.HasPrefix(p.base, ".") || strings.HasSuffix(p.base, ".") {
+		return hostPattern{}, fmt.Errorf("invalid host %s", host)
+	}
+
+	return p, nil
+}
+
+// match reports whether the normalized host fits the pattern.
+func (p hostPattern) match(host string) bool {
+	switch p.kind {
+	case hostSuffix:
+		return strings.HasSuffix(host, "."+p.base)
+	case hostTLD:
+		tld, ok := strings.CutPrefix(host, p.base+".")
+		return ok && isTLD(tld)
+	default:
+		return host == p.base
+	}
+}
+
+// isTLD reports whether s is a top-level domain or "com." under a country
+// code, and listed as such in the public suffix list: "de", "com.br", but not
+// "evil.example" nor "com.attacker.net".
+func isTLD(s string) bool {
+	labels := strings.Split(s, ".")
+	switch {
+	case len(labels) == 1:
+	case len(labels) == 2 && labels[0] == "com" && len(labels[1]) == 2:
+	default:
+		return false
+	}
+	suffix, icann := publicsuffix.PublicSuffix(s)
+	return icann && suffix == s
+}
+
+// moreSpecific orders wildcard patterns by precedence: more fixed labels
+// first, then suffix before TLD wildcards, then alphabetically so the order
+// never depends on registration. Two different patterns of the same rank can't
+// both match one host, so the first match in this order is the only
+// candidate.
+func (p hostPattern) moreSpecific(o hostPattern) bool {
+	pl, ol := strings.Count(p.base, ".")+1, strings.Count(o.base, ".")+1
+	if pl != ol {
+		return pl > ol
+	}
+	if p.kind != o.kind {
+		return p.kind == hostSuffix
+	}
+	return p.raw < o.raw
+}
+
+// matchHost reports whether the normalized host fits the registered host,
+// which may be a wildcard.
+func matchHost(registered, host string) bool {
+	p, err := parseHostPattern(registered)
+	if err != nil {
+		return false
+	}
+	return p.match(host)
+}
@@ -1,40 +1,20 @@
 package extractor
 
-import (
-	extInstagram "github.com/sxwebdev/downloaderbot/pkg/extractor/instagram"
-	extLux "github.com/sxwebdev/downloaderbot/pkg/extractor/lux"
-	extTiktok "github.com/sxwebdev/downloaderbot/pkg/extractor/tiktok"
-	extYoutube "github.com/sxwebdev/downloaderbot/pkg/extractor/youtube"
-)
-
-// DefaultRegistry is the global extractor registry
+// DefaultRegistry is the global extractor registry. Extractor packages register
+// themselves into it from their init functions, so importing a package (e.g.
+// pkg/extractor/instagram) is what makes its source available.
 var DefaultRegistry = NewRegistry()
 
-func init() {
-	// Register Instagram extractor (custom implementation)
-	if err := DefaultRegistry.Register(extInstagram.New()); err != nil {
-		panic("register instagram extractor: " + err.Error())
-	}
-
-	// Register YouTube extractor (custom implementation)
-	if err := DefaultRegistry.Register(extYoutube.New()); err != nil {
-		panic("register youtube extractor: " + err.Error())
-	}
-
-	// Register TikTok extractor (custom rod-based implementation)
-	if err := DefaultRegistry.Register(extTiktok.New()); err != nil {
-		panic("register tiktok extractor: " + err.Error())
-	}
-
-	// Register all lux-based extractors
-	for _, ext := range extLux.GetAllExtractors() {
-		if err := DefaultRegistry.Register(ext); err != nil {
-			panic("register lux extractor " + ext.Name() + ": " + err.Error())
-		}
-	}
-}
-
 // GetRegistry returns the default extractor registry
 func GetRegistry() *Registry {
 	return DefaultRegistry
 }
+
+// MustRegister adds ext to the default registry and panics on failure. It is
+// meant for the init functions of extractor packages, where a registration
+// conflict is a programming error.
+func MustRegister(ext Extractor, opts ...RegisterOption) {
+	if err := DefaultRegistry.Register(ext, opts...); err != nil {
+		panic("register " + ext.Name() + " extractor: " + err.Error())
+	}
+}
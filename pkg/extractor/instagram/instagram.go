@@ -2,27 +2,53 @@ package instagram
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
+	"sync/atomic"
 
 	"github.com/sxwebdev/downloaderbot/internal/models"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
 	"github.com/sxwebdev/downloaderbot/pkg/instagram"
 )
 
+func init() {
+	extractor.MustRegister(New())
+}
+
+// fetcher is the fetcher of the extractors, set by Configure; nil for the
+// default chain.
+var fetcher atomic.Pointer[instagram.Fetcher]
+
+// Configure sets the fetcher the Instagram extractor gets posts with, usually
+// an instagram.ChainFetcher of the configured strategies.
+func Configure(f instagram.Fetcher) {
+	fetcher.Store(&f)
+}
+
 // Extractor implements the extractor.Extractor interface for Instagram
 type Extractor struct {
 	fetcher instagram.Fetcher
 }
 
-// New creates a new Instagram extractor. It uses the browser-based fetcher by
-// default, which drives a real headless Chromium and so bypasses Instagram's
-// anti-bot challenges that break the legacy HTTP fetcher. The legacy fetcher
-// remains available via instagram.NewAPIFetcher().
+// New creates a new Instagram extractor. Until Configure is called it tries
+// the GraphQL endpoint and the embed page before loading the post in a real
+// headless Chromium, which bypasses the anti-bot challenges Instagram serves
+// to the HTTP strategies.
 func New() *Extractor {
 	return &Extractor{
-		fetcher: instagram.NewBrowserFetcher(),
+		fetcher: instagram.NewChainFetcher(instagram.DefaultStrategies()),
 	}
 }
 
+func (e *Extractor) getFetcher() instagram.Fetcher {
+	if f := fetcher.Load(); f != nil {
+		return *f
+	}
+	return e.fetcher
+}
+
 // Name returns the extractor name
 func (e *Extractor) Name() string {
 	return string(models.MediaSourceInstagram)
@@ -30,9 +56,61 @@ func (e *Extractor) Name() string {
 
 // Hosts returns the supported hosts.
 // `www.` / `m.` / `mobile.` prefixes are normalized by the registry, so we only
-// list the canonical form here.
+// list the canonical form here, plus the country domains (instagram.com.br).
 func (e *Extractor) Hosts() []string {
-	return []string{"instagram.com"}
+	return []string{"instagram.com", "instagram.*"}
+}
+
+// rePost matches the post, reel and IGTV paths instagram.ExtractShortcodeFromLink
+// understands, optionally prefixed with the author's username.
+var rePost = regexp.MustCompile(`^/(?:[\w.]+/)?(?:p|tv|reel|reels/videos)/[A-Za-z0-9_-]+`)
+
+// Patterns limits the extractor to posts and reels, so profile, stories and
+// explore links are rejected before the browser is started.
+func (e *Extractor) Patterns() []extractor.Pattern {
+	return []extractor.Pattern{{Path: rePost}}
+}
+
+// Canonicalize implements extractor.Canonicalizer: posts, reels and IGTV videos
+// all open under https://www.instagram.com/p/<shortcode>/.
+func (e *Extractor) Canonicalize(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	code, err := instagram.ExtractShortcodeFromLink(u.Path)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", extractor.ErrUnsupportedURL, err)
+	}
+	return "https://www.instagram.com/p/" + code + "/", nil
+}
+
+// Capabilities declares Instagram posts as publicly fetchable photos and
+// videos (single or carousel), plus the soundtrack of reels, that can be sent
+// inline.
+func (e *Extractor) Capabilities() extractor.Capabilities {
+	return extractor.Capabilities{
+		Inline:     true,
+		MediaTypes: []models.MediaType{models.MediaTypeVideo, models.MediaTypePhoto, models.MediaTypeAudio},
+		Output:     extractor.OutputItems,
+	}
+}
+
+// errorKinds maps pkg/instagram errors to the extractor error taxonomy.
+var errorKinds = map[error]error{
+	instagram.ErrNotFound:      extractor.ErrNotFound,
+	instagram.ErrPrivate:       extractor.ErrPrivate,
+	instagram.ErrLoginRequired: extractor.ErrLoginRequired,
+	instagram.ErrNoMedia:       extractor.ErrUpstreamChanged,
+}
+
+func errorKind(err error) error {
+	for target, kind := range errorKinds {
+		if errors.Is(err, target) {
+			return kind
+		}
+	}
+	return nil
 }
 
 // Extract extracts media from Instagram URL
@@ -40,12 +118,15 @@ func (e *Extractor) Extract(ctx context.Context, url string) (*models.Media, err
 	// Extract shortcode from URL
 	code, err := instagram.ExtractShortcodeFromLink(url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract shortcode: %w", err)
+		return nil, fmt.Errorf("failed to extract shortcode: %w: %w", extractor.ErrUnsupportedURL, err)
 	}
 
 	// Get media data
-	media, err := e.fetcher.GetPost(ctx, code)
+	media, err := e.getFetcher().GetPost(ctx, code)
 	if err != nil {
+		if kind := errorKind(err); kind != nil {
+			return nil, fmt.Errorf("failed to get post: %w: %w", kind, err)
+		}
 		return nil, fmt.Errorf("failed to get post: %w", err)
 	}
 
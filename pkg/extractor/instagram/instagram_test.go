@@ -0,0 +1,34 @@
+package instagram
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
+)
+
+func TestCanonicalize(t *testing.T) {
+	const want = "https://www.instagram.com/p/Cxyz_12-3/"
+	links := []string{
+		"https://www.instagram.com/reel/Cxyz_12-3/?igsh=MWQ1ZGUxMzBkMA==",
+		"https://instagram.com/p/Cxyz_12-3",
+		"https://www.instagram.com/reels/videos/Cxyz_12-3/",
+		"https://www.instagram.com/some.user/p/Cxyz_12-3/?utm_source=ig_web_copy_link",
+		"https://www.instagram.com/tv/Cxyz_12-3/",
+	}
+
+	e := &Extractor{}
+	for _, link := range links {
+		got, err := e.Canonicalize(link)
+		if err != nil {
+			t.Fatalf("Canonicalize(%s): %v", link, err)
+		}
+		if got != want {
+			t.Errorf("Canonicalize(%s) = %s, want %s", link, got, want)
+		}
+	}
+
+	if _, err := e.Canonicalize("https://www.instagram.com/some.user/"); !errors.Is(err, extractor.ErrUnsupportedURL) {
+		t.Fatalf("want ErrUnsupportedURL for a profile, got %v", err)
+	}
+}
@@ -1,12 +1,25 @@
 package lux
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/iawia002/lux/extractors"
+	"github.com/iawia002/lux/request"
 	"github.com/sxwebdev/downloaderbot/internal/models"
+	"github.com/sxwebdev/downloaderbot/internal/util"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
+	"github.com/sxwebdev/downloaderbot/pkg/tiktok"
 
 	// Import all available lux extractors to register them
 	// Note: Some extractors from lux master branch are not available in v0.24.1
@@ -52,6 +65,21 @@ import (
 	_ "github.com/iawia002/lux/extractors/zingmp3"
 )
 
+func init() {
+	// lux requests share the bot's transport and its timeouts.
+	request.SetClient(util.DefaultHttpClient())
+
+	for _, ext := range GetAllExtractors() {
+		extractor.MustRegister(ext)
+	}
+
+	// lux extractors for hosts that have a custom extractor are kept as a
+	// backup, tried when the custom one reports it is blocked.
+	for _, ext := range GetFallbackExtractors() {
+		extractor.MustRegister(ext, extractor.WithPriority(extractor.PriorityFallback))
+	}
+}
+
 // siteToSource maps lux site names to MediaSource
 var siteToSource = map[string]models.MediaSource{
 	"acfun":        models.MediaSourceAcfun,
@@ -99,7 +127,8 @@ var siteToSource = map[string]models.MediaSource{
 // hostToSite maps host patterns to lux site names
 var hostToSite = map[string]string{
 	// TikTok is handled by the custom rod-based extractor (pkg/extractor/tiktok),
-	// because lux's TikTok extractor is blocked by anti-bot. Do not map it here.
+	// because lux's TikTok extractor is blocked by anti-bot. Do not map it here;
+	// lux's TikTok stays behind it as a fallback (see fallbackHostToSite).
 	// Twitter/X (note: `mobile.` and `www.` are normalized away by the registry)
 	"twitter.com": "twitter",
 	"x.com":       "twitter",
@@ -118,7 +147,8 @@ var hostToSite = map[string]string{
 	"pinterest.com": "pinterest",
 	"pin.it":        "pinterest",
 	// Tumblr
-	"tumblr.com": "tumblr",
+	"tumblr.com":   "tumblr",
+	"*.tumblr.com": "tumblr",
 	// Bilibili
 	"bilibili.com": "bilibili",
 	"b23.tv":       "bilibili",
@@ -195,18 +225,134 @@ var hostToSite = map[string]string{
 	"zingmp3.vn": "zingmp3",
 }
 
+// fallbackHostToSite maps hosts served by a custom extractor to the lux site
+// kept behind it as a fallback.
+var fallbackHostToSite = map[string]string{
+	// TikTok's custom extractor drives a real browser; lux's plain HTTP one is
+	// often blocked by anti-bot, but it costs nothing to try when the browser
+	// path is blocked too.
+	"tiktok.com":    "tiktok",
+	"*.tiktok.com":  "tiktok",
+	"vt.tiktok.com": "tiktok",
+	"vm.tiktok.com": "tiktok",
+}
+
+// fallbackPatterns restricts a fallback to the links its custom extractor
+// accepts; otherwise an unsupported link shape would still reach lux.
+var fallbackPatterns = map[string][]extractor.Pattern{
+	"tiktok": {{Match: tiktok.IsVideoLink}},
+}
+
+// siteShareParams are the share and tracking query parameters a site adds to
+// its links on top of the ones extractor.NormalizeURL drops anyway.
+var siteShareParams = map[string][]string{
+	"bilibili": {"spm_id_from", "vd_source", "share_source", "share_medium", "share_plat", "share_session_id", "share_tag", "bbid", "ts", "unique_k", "up_id"},
+	"twitter":  {"s", "t"},
+	"reddit":   {"share_id", "context"},
+	"facebook": {"mibextid", "rdid", "sfnsn"},
+	"weibo":    {"from", "wm"},
+}
+
+// fallbackPrefix names fallback extractors apart from the custom extractor
+// that claims the same source name.
+const fallbackPrefix = "lux-"
+
 // Extractor implements the extractor.Extractor interface using lux library
 type Extractor struct {
-	name  string
-	hosts []string
+	name     string
+	site     string
+	hosts    []string
+	patterns []extractor.Pattern
+	timeout  time.Duration
+}
+
+// DefaultTimeout bounds a single lux extraction unless WithTimeout says
+// otherwise.
+const DefaultTimeout = 30 * time.Second
+
+// Option configures an Extractor.
+type Option func(*Extractor)
+
+// WithTimeout sets how long an extraction of the site may take.
+func WithTimeout(d time.Duration) Option {
+	return func(e *Extractor) { e.timeout = d }
+}
+
+// SiteOptions configures the extraction of a lux site.
+type SiteOptions struct {
+	// Cookie is sent with the site's requests, in the Netscape cookie file
+	// format or as a Cookie header value. Only the sites of cookieSites use
+	// it, to serve the best qualities or members-only videos.
+	Cookie string
+	// Playlist extracts every entry of a multi-part video, gallery or
+	// playlist instead of the linked one only; Items, ItemStart and ItemEnd
+	// (1-based) pick some of them, like "1,5,6,8-10".
+	Playlist  bool
+	Items     string
+	ItemStart int
+	ItemEnd   int
+	// Timeout replaces the extractor's own timeout when set.
+	Timeout time.Duration
+}
+
+// siteOptions holds the options set by Configure, by site name.
+var siteOptions atomic.Pointer[map[string]SiteOptions]
+
+// cookieSites are the sites whose lux extractor reads Options.Cookie (as of
+// lux v0.24.1); the others would ignore a cookie.
+var cookieSites = map[string]bool{
+	"bilibili": true,
+	"ixigua":   true,
+	"youku":    true,
+}
+
+// Configure sets the options of lux sites by site name (bilibili, weibo, ...).
+// Sites missing from opts are extracted with the defaults. It is meant to be
+// called once at startup.
+func Configure(opts map[string]SiteOptions) error {
+	for site, o := range opts {
+		if _, ok := siteToSource[site]; !ok {
+			return fmt.Errorf("unknown lux site %q", site)
+		}
+		if o.Cookie != "" && !cookieSites[site] {
+			return fmt.Errorf("lux site %q doesn't use cookies, only %s do", site, strings.Join(slices.Sorted(maps.Keys(cookieSites)), ", "))
+		}
+	}
+	siteOptions.Store(&opts)
+	return nil
+}
+
+// optionsFor returns the options configured for site.
+func optionsFor(site string) SiteOptions {
+	opts := siteOptions.Load()
+	if opts == nil {
+		return SiteOptions{}
+	}
+	return (*opts)[site]
 }
 
 // NewExtractor creates a new lux-based extractor for a specific site
-func NewExtractor(siteName string, hosts []string) *Extractor {
-	return &Extractor{
-		name:  siteName,
-		hosts: hosts,
+func NewExtractor(siteName string, hosts []string, opts ...Option) *Extractor {
+	e := &Extractor{
+		name:    siteName,
+		site:    siteName,
+		hosts:   hosts,
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(e)
 	}
+	return e
+}
+
+// NewFallbackExtractor creates a lux-based extractor for a site that also has
+// a custom extractor. It is named apart from the custom one ("lux-<site>") but
+// still reports the site's source on the extracted media.
+func NewFallbackExtractor(siteName string, hosts []string, opts ...Option) *Extractor {
+	e := NewExtractor(siteName, hosts, opts...)
+	e.name = fallbackPrefix + siteName
+	e.patterns = fallbackPatterns[siteName]
+	return e
 }
 
 // Name returns the extractor name
@@ -219,97 +365,276 @@ func (e *Extractor) Hosts() []string {
 	return e.hosts
 }
 
-// Extract extracts media from the URL using lux
+// Patterns returns the URL shapes the extractor is limited to. Only fallback
+// extractors have any; lux sites otherwise accept every link of their hosts.
+func (e *Extractor) Patterns() []extractor.Pattern {
+	return e.patterns
+}
+
+// Canonicalize implements extractor.Canonicalizer. TikTok links get the form
+// of the custom TikTok extractor, so both share cache entries; other sites
+// have no ID-based form here and get extractor.NormalizeURL without their
+// share parameters.
+func (e *Extractor) Canonicalize(rawURL string) (string, error) {
+	if e.site == "tiktok" {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return "", err
+		}
+		if canonical, ok := tiktok.CanonicalURL(u); ok {
+			return canonical, nil
+		}
+	}
+	return extractor.NormalizeURL(rawURL, siteShareParams[e.site]...)
+}
+
+// Capabilities declares lux sites as publicly fetchable media of any type:
+// depending on the site lux returns videos, audio tracks or images.
+func (e *Extractor) Capabilities() extractor.Capabilities {
+	return extractor.Capabilities{
+		Inline:     true,
+		MediaTypes: []models.MediaType{models.MediaTypeVideo, models.MediaTypeAudio, models.MediaTypePhoto},
+		Output:     extractor.OutputItems,
+	}
+}
+
+// reHTTPStatus matches the status lux reports for a failed request, which it
+// only gives as text.
+var reHTTPStatus = regexp.MustCompile(`request error: HTTP (\d{3})`)
+
+// errorKind maps lux errors to the extractor error taxonomy.
+func errorKind(err error) error {
+	switch {
+	case errors.Is(err, extractors.ErrURLParseFailed):
+		return extractor.ErrUnsupportedURL
+	case errors.Is(err, extractors.ErrBodyParseFailed), errors.Is(err, extractors.ErrInvalidRegularExpression):
+		return extractor.ErrUpstreamChanged
+	}
+	if m := reHTTPStatus.FindStringSubmatch(err.Error()); m != nil {
+		status, _ := strconv.Atoi(m[1])
+		return extractor.StatusError(status)
+	}
+	return nil
+}
+
+// extract is extractors.Extract, replaceable in tests.
+var extract = extractors.Extract
+
+// run calls lux for url under ctx, which cancels its requests. A panic of a
+// lux extractor is returned as an error.
+func run(ctx context.Context, url string, opts extractors.Options) (data []*extractors.Data, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("lux panicked: %v", r)
+		}
+	}()
+
+	data, err = extract(ctx, url, opts)
+	if ctxErr := ctx.Err(); err != nil && ctxErr != nil {
+		// Extractors may report a canceled request as a parse error.
+		return nil, ctxErr
+	}
+	return data, err
+}
+
+// Extract extracts media from the URL using lux, canceling its requests when
+// ctx ends or the site's timeout passes.
 func (e *Extractor) Extract(ctx context.Context, url string) (*models.Media, error) {
-	opts := extractors.Options{}
+	site := optionsFor(e.site)
 
-	dataList, err := extractors.Extract(url, opts)
-	if err != nil {
-		return nil, fmt.Errorf("lux extraction failed: %w", err)
+	timeout := e.timeout
+	if site.Timeout > 0 {
+		timeout = site.Timeout
 	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	if len(dataList) == 0 {
-		return nil, fmt.Errorf("no data extracted from URL")
+	opts := extractors.Options{
+		Cookie:    site.Cookie,
+		Playlist:  site.Playlist,
+		Items:     site.Items,
+		ItemStart: site.ItemStart,
+		ItemEnd:   site.ItemEnd,
 	}
 
-	// Use the first data item
-	data := dataList[0]
-	if data.Err != nil {
-		return nil, fmt.Errorf("extraction error: %w", data.Err)
+	dataList, err := run(ctx, url, opts)
+	if err != nil {
+		if kind := errorKind(err); kind != nil {
+			return nil, fmt.Errorf("lux extraction failed: %w: %w", kind, err)
+		}
+		return nil, fmt.Errorf("lux extraction failed: %w", err)
 	}
 
 	// Convert lux data to our Media model
-	media := convertLuxDataToMedia(data, url)
+	media, err := convertLuxDataToMedia(dataList, url)
+	if err != nil {
+		if kind := errorKind(err); kind != nil {
+			return nil, fmt.Errorf("extraction error: %w: %w", kind, err)
+		}
+		return nil, fmt.Errorf("extraction error: %w", err)
+	}
 
-	// Set the source based on the extractor name
-	if source, ok := siteToSource[e.name]; ok {
+	// Set the source based on the lux site
+	if source, ok := siteToSource[e.site]; ok {
 		media.Source = source
 	} else {
-		media.Source = models.MediaSource(e.name)
+		media.Source = models.MediaSource(e.site)
 	}
 
 	return media, nil
 }
 
-// convertLuxDataToMedia converts lux Data to our Media model
-func convertLuxDataToMedia(data *extractors.Data, requestURL string) *models.Media {
+// convertLuxDataToMedia converts lux Data to our Media model. Multi-part
+// videos, galleries and playlists come as one Data per entry, whose items are
+// merged in order under the title of the first one. Entries that failed are
+// skipped, unless all of them did: then the first error is returned.
+func convertLuxDataToMedia(dataList []*extractors.Data, requestURL string) (*models.Media, error) {
 	media := &models.Media{
 		RequestUrl: requestURL,
-		Title:      data.Title,
 		Items:      make([]*models.MediaItem, 0),
 	}
 
-	// Iterate over streams to get quality options
+	var extracted bool
+	var firstErr error
+	for i, data := range dataList {
+		if data == nil {
+			continue
+		}
+		if data.Err != nil {
+			if firstErr == nil {
+				firstErr = data.Err
+			}
+			continue
+		}
+
+		items := dataItems(data)
+		if len(dataList) > 1 {
+			// Keep the IDs unique across entries.
+			for _, item := range items {
+				item.Id = fmt.Sprintf("%d-%s", i+1, item.Id)
+			}
+		}
+		if !extracted {
+			media.Title = data.Title
+			extracted = true
+		}
+		media.Items = append(media.Items, items...)
+	}
+
+	if !extracted {
+		if firstErr != nil {
+			return nil, firstErr
+		}
+		return nil, fmt.Errorf("%w: no data extracted from URL", extractor.ErrNotSupported)
+	}
+
+	return media, nil
+}
+
+// dataItems converts a single lux Data to media items. The streams of a Data
+// are the qualities of one entry: the single-file ones become the variants of
+// one item, best first. Streams split into parts (segments, or images fetched
+// at once) can't be offered as one file; when there is nothing else, the
+// parts of the largest such stream become items of their own.
+func dataItems(data *extractors.Data) []*models.MediaItem {
+	// Determine media type based on lux data type
+	mediaType := models.MediaTypeVideo
+	switch data.Type {
+	case extractors.DataTypeAudio:
+		mediaType = models.MediaTypeAudio
+	case extractors.DataTypeImage:
+		mediaType = models.MediaTypePhoto
+	}
+
+	streamIDs := make([]string, 0, len(data.Streams))
 	for streamID, stream := range data.Streams {
-		if stream == nil || len(stream.Parts) == 0 {
+		if stream != nil && len(stream.Parts) > 0 {
+			streamIDs = append(streamIDs, streamID)
+		}
+	}
+	// Largest first; the ID keeps the order stable across equal sizes.
+	slices.SortFunc(streamIDs, func(a, b string) int {
+		return cmp.Or(cmp.Compare(streamSize(data.Streams[b]), streamSize(data.Streams[a])), strings.Compare(a, b))
+	})
+
+	variants := make([]*models.Variant, 0, len(streamIDs))
+	for _, streamID := range streamIDs {
+		stream := data.Streams[streamID]
+		part := stream.Parts[0]
+		if len(stream.Parts) > 1 || part == nil || part.URL == "" {
 			continue
 		}
+		variants = append(variants, &models.Variant{
+			Url:           part.URL,
+			Quality:       streamQuality(streamID, stream),
+			MimeType:      getMimeType(part.Ext, mediaType),
+			ContentLength: part.Size,
+			HasVideo:      mediaType.IsVideo(),
+			HasAudio:      mediaType.IsAudio() || (mediaType.IsVideo() && !stream.NeedMux),
+		})
+	}
 
-		// Determine media type based on lux data type
-		mediaType := models.MediaTypeVideo
-		switch data.Type {
-		case extractors.DataTypeAudio:
-			mediaType = models.MediaTypeAudio
-		case extractors.DataTypeImage:
-			mediaType = models.MediaTypePhoto
+	if len(variants) > 0 {
+		item := (&models.MediaItem{Id: streamIDs[0], Type: mediaType}).WithVariant(variants[0])
+		if len(variants) > 1 {
+			item.Variants = variants
 		}
+		return []*models.MediaItem{item}
+	}
 
-		// For each part in the stream, create a media item
+	items := make([]*models.MediaItem, 0)
+	for _, streamID := range streamIDs {
+		stream := data.Streams[streamID]
 		for partIdx, part := range stream.Parts {
 			if part == nil || part.URL == "" {
 				continue
 			}
-
-			quality := stream.Quality
-			if quality == "" {
-				quality = streamID
-			}
-			if len(stream.Parts) > 1 {
-				quality = fmt.Sprintf("%s-part%d", quality, partIdx+1)
-			}
-
-			item := &models.MediaItem{
+			items = append(items, &models.MediaItem{
 				Id:            fmt.Sprintf("%s-%d", streamID, partIdx),
 				Type:          mediaType,
 				Url:           part.URL,
-				Quality:       quality,
+				Quality:       fmt.Sprintf("%s-part%d", streamQuality(streamID, stream), partIdx+1),
 				ContentLength: part.Size,
 				MimeType:      getMimeType(part.Ext, mediaType),
-			}
-
-			media.Items = append(media.Items, item)
+			})
+		}
+		if len(items) > 0 {
+			break
 		}
 	}
 
 	// If no items from streams, try to get any available data
-	if len(media.Items) == 0 && data.URL != "" {
-		media.Items = append(media.Items, &models.MediaItem{
+	if len(items) == 0 && data.URL != "" {
+		items = append(items, &models.MediaItem{
 			Type: models.MediaTypeVideo,
 			Url:  data.URL,
 		})
 	}
 
-	return media
+	return items
+}
+
+// streamQuality returns the quality label of a stream, its ID when lux gives
+// none.
+func streamQuality(streamID string, stream *extractors.Stream) string {
+	if stream.Quality != "" {
+		return stream.Quality
+	}
+	return streamID
+}
+
+// streamSize returns the size of a stream, summing its parts when lux didn't.
+func streamSize(stream *extractors.Stream) int64 {
+	if stream.Size > 0 {
+		return stream.Size
+	}
+	var size int64
+	for _, part := range stream.Parts {
+		if part != nil {
+			size += part.Size
+		}
+	}
+	return size
 }
 
 // getMimeType returns MIME type based on extension and media type
@@ -378,6 +703,22 @@ func GetAllExtractors() []*Extractor {
 	return extractors
 }
 
+// GetFallbackExtractors returns the lux-based extractors kept as a backup
+// behind custom extractors
+func GetFallbackExtractors() []*Extractor {
+	siteHosts := make(map[string][]string)
+	for host, site := range fallbackHostToSite {
+		siteHosts[site] = append(siteHosts[site], host)
+	}
+
+	extractors := make([]*Extractor, 0, len(siteHosts))
+	for site, hosts := range siteHosts {
+		extractors = append(extractors, NewFallbackExtractor(site, hosts))
+	}
+
+	return extractors
+}
+
 // GetExtractorBySite returns an extractor for a specific site
 func GetExtractorBySite(siteName string) *Extractor {
 	var hosts []string
@@ -0,0 +1,232 @@
+package lux
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iawia002/lux/extractors"
+	"github.com/sxwebdev/downloaderbot/internal/models"
+
+	// Serves hosts without a lux site, such as the stand-in below.
+	_ "github.com/iawia002/lux/extractors/universal"
+)
+
+// A stand-in site on 127.0.0.1 is served by lux's universal extractor, which
+// asks the server for the size and type of the file.
+func newStandIn(t *testing.T) (srv *httptest.Server, release func()) {
+	t.Helper()
+	hang := make(chan struct{})
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/hang.mp4" {
+			select {
+			case <-hang:
+			case <-r.Context().Done():
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "video/mp4")
+		w.Header().Set("Content-Length", "1024")
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() { close(hang) }
+}
+
+func TestExtract(t *testing.T) {
+	srv, release := newStandIn(t)
+	defer release()
+
+	e := NewExtractor("universal", []string{"127.0.0.1"})
+	media, err := e.Extract(context.Background(), srv.URL+"/clip.mp4")
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if len(media.Items) != 1 || media.Items[0].Url != srv.URL+"/clip.mp4" || media.Items[0].Type != models.MediaTypeVideo {
+		t.Fatalf("unexpected media %+v", media)
+	}
+}
+
+func TestExtract_Cancellation(t *testing.T) {
+	srv, release := newStandIn(t)
+	defer release()
+
+	t.Run("site timeout", func(t *testing.T) {
+		e := NewExtractor("universal", []string{"127.0.0.1"}, WithTimeout(50*time.Millisecond))
+		start := time.Now()
+		_, err := e.Extract(context.Background(), srv.URL+"/hang.mp4")
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("want context.DeadlineExceeded, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Fatalf("Extract returned after %v", elapsed)
+		}
+	})
+
+	t.Run("caller gives up", func(t *testing.T) {
+		e := NewExtractor("universal", []string{"127.0.0.1"})
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+		_, err := e.Extract(ctx, srv.URL+"/hang.mp4")
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("want context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestExtract_CancelsRequests(t *testing.T) {
+	canceled := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(canceled)
+	}))
+	defer srv.Close()
+
+	e := NewExtractor("universal", []string{"127.0.0.1"}, WithTimeout(50*time.Millisecond))
+	if _, err := e.Extract(context.Background(), srv.URL+"/hang.mp4"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want context.DeadlineExceeded, got %v", err)
+	}
+	// The request lux sent is canceled, not left to finish in the background.
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("the lux request outlived the extraction")
+	}
+}
+
+func TestExtract_Panic(t *testing.T) {
+	orig := extract
+	t.Cleanup(func() { extract = orig })
+	extract = func(context.Context, string, extractors.Options) ([]*extractors.Data, error) {
+		panic("unexpected page")
+	}
+
+	_, err := NewExtractor("universal", nil).Extract(context.Background(), "https://example.test/a")
+	if err == nil {
+		t.Fatal("want the panic as an error")
+	}
+}
+
+func TestExtract_SiteOptions(t *testing.T) {
+	orig := extract
+	t.Cleanup(func() {
+		extract = orig
+		_ = Configure(nil)
+	})
+
+	var got extractors.Options
+	extract = func(_ context.Context, _ string, opts extractors.Options) ([]*extractors.Data, error) {
+		got = opts
+		part := func(url string) map[string]*extractors.Stream {
+			return map[string]*extractors.Stream{"default": {Parts: []*extractors.Part{{URL: url, Ext: "jpg"}}}}
+		}
+		return []*extractors.Data{
+			{Title: "note", Type: extractors.DataTypeImage, Streams: part("https://cdn.test/1.jpg")},
+			{Err: errors.New("entry removed")},
+			{Title: "note", Type: extractors.DataTypeImage, Streams: part("https://cdn.test/3.jpg")},
+		}, nil
+	}
+
+	if err := Configure(map[string]SiteOptions{"nosuchsite": {}}); err == nil {
+		t.Fatal("want an error for an unknown site")
+	}
+	// lux would ignore the cookie.
+	if err := Configure(map[string]SiteOptions{"xiaohongshu": {Cookie: "a=b"}}); err == nil {
+		t.Fatal("want an error for a cookie of a site that doesn't use it")
+	}
+	err := Configure(map[string]SiteOptions{
+		"xiaohongshu": {Playlist: true, Items: "1-3"},
+		"bilibili":    {Cookie: "a=b"},
+	})
+	if err != nil {
+		t.Fatalf("Configure: %v", err)
+	}
+
+	media, err := NewExtractor("xiaohongshu", nil).Extract(context.Background(), "https://www.xiaohongshu.com/explore/1")
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if !got.Playlist || got.Items != "1-3" {
+		t.Fatalf("options not passed to lux: %+v", got)
+	}
+	if media.Title != "note" || len(media.Items) != 2 ||
+		media.Items[0].Url != "https://cdn.test/1.jpg" || media.Items[1].Url != "https://cdn.test/3.jpg" ||
+		media.Items[0].Id == media.Items[1].Id || media.Items[0].Type != models.MediaTypePhoto {
+		t.Fatalf("entries not merged: %+v", media.Items)
+	}
+
+	if _, err := NewExtractor("bilibili", nil).Extract(context.Background(), "https://www.bilibili.com/video/BV1"); err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if got.Cookie != "a=b" {
+		t.Fatalf("cookie not passed to lux: %+v", got)
+	}
+
+	// Other sites keep the defaults.
+	if _, err := NewExtractor("weibo", nil).Extract(context.Background(), "https://weibo.com/1/2"); err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if got != (extractors.Options{}) {
+		t.Fatalf("unconfigured site got options %+v", got)
+	}
+}
+
+func TestConvertLuxDataToMedia_AllFailed(t *testing.T) {
+	wantErr := errors.New("gone")
+	_, err := convertLuxDataToMedia([]*extractors.Data{{Err: wantErr}, {Err: errors.New("also gone")}}, "https://example.test")
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("want the first entry error, got %v", err)
+	}
+}
+
+func TestConvertLuxDataToMedia_Variants(t *testing.T) {
+	single := func(url string, size int64) *extractors.Stream {
+		return &extractors.Stream{Size: size, Parts: []*extractors.Part{{URL: url, Size: size, Ext: "mp4"}}}
+	}
+
+	media, err := convertLuxDataToMedia([]*extractors.Data{{
+		Type: extractors.DataTypeVideo,
+		Streams: map[string]*extractors.Stream{
+			"480":  single("https://cdn.test/480.mp4", 100),
+			"1080": single("https://cdn.test/1080.mp4", 300),
+			"720":  single("https://cdn.test/720.mp4", 200),
+			"segmented": {Size: 900, Parts: []*extractors.Part{
+				{URL: "https://cdn.test/seg1.mp4"}, {URL: "https://cdn.test/seg2.mp4"},
+			}},
+		},
+	}}, "https://example.test/v")
+	if err != nil {
+		t.Fatalf("convertLuxDataToMedia: %v", err)
+	}
+	if len(media.Items) != 1 {
+		t.Fatalf("want the qualities as variants of one item, got %d items", len(media.Items))
+	}
+	item := media.Items[0]
+	if item.Url != "https://cdn.test/1080.mp4" || item.Quality != "1080" || item.ContentLength != 300 {
+		t.Fatalf("want the largest stream by default, got %+v", item)
+	}
+	var got []string
+	for _, v := range item.Variants {
+		got = append(got, v.Quality)
+	}
+	if len(got) != 3 || got[0] != "1080" || got[1] != "720" || got[2] != "480" {
+		t.Fatalf("unexpected variants %v", got)
+	}
+
+	// Only segmented streams: the parts of the largest one are the items.
+	media, err = convertLuxDataToMedia([]*extractors.Data{{
+		Type: extractors.DataTypeImage,
+		Streams: map[string]*extractors.Stream{
+			"small": {Parts: []*extractors.Part{{URL: "https://cdn.test/s1.jpg", Size: 1}, {URL: "https://cdn.test/s2.jpg", Size: 1}}},
+			"large": {Parts: []*extractors.Part{{URL: "https://cdn.test/l1.jpg", Size: 5}, {URL: "https://cdn.test/l2.jpg", Size: 5}}},
+		},
+	}}, "https://example.test/g")
+	if err != nil {
+		t.Fatalf("convertLuxDataToMedia: %v", err)
+	}
+	if len(media.Items) != 2 || media.Items[0].Url != "https://cdn.test/l1.jpg" || media.Items[1].Quality != "large-part2" {
+		t.Fatalf("unexpected items %+v", media.Items)
+	}
+}
@@ -0,0 +1,257 @@
+// Package plugin runs external programs as extractors, so a site that an
+// existing command-line tool already handles can be added in the config
+// rather than in code.
+//
+// A plugin is called with the link as its last argument. It prints a single
+// JSON document to stdout and exits with status 0:
+//
+//	{
+//	  "title": "A clip",
+//	  "caption": "What happened today",
+//	  "thumbnail": "https://cdn.example.com/cover.jpg",
+//	  "items": [
+//	    {
+//	      "type": "video",                        // video, photo or audio
+//	      "url": "https://cdn.example.com/720.mp4",
+//	      "quality": "720p",
+//	      "mime_type": "video/mp4",
+//	      "width": 1280, "height": 720,
+//	      "duration": 90,                         // seconds
+//	      "content_length": 10485760,             // bytes
+//	      "thumbnail": "https://cdn.example.com/cover.jpg",
+//	      "video_without_audio": false,
+//	      "headers": {"Referer": "https://example.com/"},
+//	      "variants": [
+//	        {"url": "...", "quality": "1080p", "mime_type": "video/mp4",
+//	         "codec": "avc1.640028, mp4a.40.2", "bitrate": 4000000,
+//	         "width": 1920, "height": 1080, "content_length": 31457280,
+//	         "has_video": true, "has_audio": true}
+//	      ]
+//	    }
+//	  ]
+//	}
+//
+// Only items[].type and items[].url are required. headers are sent with every
+// download of the item and its variants; a plugin returning them should be
+// configured with download_headers, since such URLs can't be handed to
+// Telegram or API clients as plain links.
+//
+// A plugin that fails prints {"error": {"kind": "not_found", "message":
+// "..."}} instead, with any exit status. The kind is one of not_supported,
+// unsupported_url, blocked, private, not_found, login_required, rate_limited,
+// geo_blocked and upstream_changed, and decides what the user is told as with
+// the built-in extractors; an unknown or missing kind is reported as an
+// unexpected failure. Any other non-zero exit is an unexpected failure too,
+// with the end of stderr in the error.
+//
+// When the request is canceled or the plugin runs past its timeout, the
+// process is killed.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/sxwebdev/downloaderbot/internal/models"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
+)
+
+// DefaultTimeout bounds a plugin run when its config sets no timeout.
+const DefaultTimeout = 30 * time.Second
+
+const (
+	// maxOutput bounds how much of stdout is read: the document lists URLs,
+	// not media.
+	maxOutput = 4 << 20
+	// maxStderr is how much of the end of stderr goes into the error of a
+	// failed run.
+	maxStderr = 1 << 10
+	// waitDelay is how long the output pipes may stay open after the process
+	// was killed, in case it left children holding them.
+	waitDelay = time.Second
+)
+
+// Config describes a plugin.
+type Config struct {
+	// Name is the extractor name, also reported as the media source.
+	Name string
+	// Hosts are the hosts the plugin handles, wildcards included.
+	Hosts []string
+	// Command is the program and its arguments; the link is appended.
+	Command []string
+	// Timeout bounds a run, DefaultTimeout when zero.
+	Timeout time.Duration
+	// Priority places the plugin in the chains of its hosts (see
+	// extractor.WithPriority).
+	Priority int
+	// Inline allows to offer the media in Telegram inline mode.
+	Inline bool
+	// DownloadHeaders declares that the media URLs need the item headers.
+	DownloadHeaders bool
+	// Formats declares the variants of the items as formats to choose from
+	// (see extractor.OutputFormats).
+	Formats bool
+}
+
+// Extractor implements the extractor.Extractor interface by running a plugin.
+type Extractor struct {
+	cfg Config
+}
+
+// New creates a plugin extractor.
+func New(cfg Config) (*Extractor, error) {
+	if cfg.Name == "" {
+		return nil, fmt.Errorf("plugin name is empty")
+	}
+	if len(cfg.Hosts) == 0 {
+		return nil, fmt.Errorf("plugin %s has no hosts", cfg.Name)
+	}
+	if len(cfg.Command) == 0 || cfg.Command[0] == "" {
+		return nil, fmt.Errorf("plugin %s has no command", cfg.Name)
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	return &Extractor{cfg: cfg}, nil
+}
+
+// Register creates the plugin extractor and adds it to the registry.
+func Register(r *extractor.Registry, cfg Config) error {
+	e, err := New(cfg)
+	if err != nil {
+		return err
+	}
+	return r.Register(e, extractor.WithPriority(cfg.Priority))
+}
+
+// Name returns the extractor name.
+func (e *Extractor) Name() string {
+	return e.cfg.Name
+}
+
+// Hosts returns the configured hosts.
+func (e *Extractor) Hosts() []string {
+	return e.cfg.Hosts
+}
+
+// Capabilities declares what the config says about the plugin output.
+func (e *Extractor) Capabilities() extractor.Capabilities {
+	output := extractor.OutputItems
+	if e.cfg.Formats {
+		output = extractor.OutputFormats
+	}
+	return extractor.Capabilities{
+		Inline:          e.cfg.Inline,
+		DownloadHeaders: e.cfg.DownloadHeaders,
+		MediaTypes:      []models.MediaType{models.MediaTypeVideo, models.MediaTypePhoto, models.MediaTypeAudio},
+		Output:          output,
+	}
+}
+
+// Extract runs the plugin for the link and converts its output.
+func (e *Extractor) Extract(ctx context.Context, url string) (*models.Media, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.cfg.Timeout)
+	defer cancel()
+
+	args := append(e.cfg.Command[1:len(e.cfg.Command):len(e.cfg.Command)], url)
+	cmd := exec.CommandContext(ctx, e.cfg.Command[0], args...)
+	cmd.WaitDelay = waitDelay
+
+	stdout := &limitedBuffer{limit: maxOutput}
+	stderr := &tailBuffer{limit: maxStderr}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	runErr := cmd.Run()
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, fmt.Errorf("plugin %s: %w", e.cfg.Name, ctxErr)
+	}
+	if stdout.overflow {
+		return nil, fmt.Errorf("plugin %s: output is larger than %d bytes", e.cfg.Name, maxOutput)
+	}
+
+	var out output
+	decodeErr := json.Unmarshal(stdout.Bytes(), &out)
+	if decodeErr == nil && out.Error != nil {
+		return nil, out.Error.err(e.cfg.Name)
+	}
+	if runErr != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("plugin %s: %w: %s", e.cfg.Name, runErr, msg)
+		}
+		return nil, fmt.Errorf("plugin %s: %w", e.cfg.Name, runErr)
+	}
+	if decodeErr != nil {
+		return nil, fmt.Errorf("plugin %s: invalid output: %w", e.cfg.Name, decodeErr)
+	}
+
+	media, err := out.Media(models.MediaSource(e.cfg.Name), url)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: invalid output: %w", e.cfg.Name, err)
+	}
+
+	return media, nil
+}
+
+// limitedBuffer keeps up to limit bytes and notes that more were written. It
+// accepts the rest without storing it, so the plugin isn't stopped by a
+// broken pipe before it exits.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit    int
+	overflow bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); len(p) > room {
+		b.overflow = true
+		b.Buffer.Write(p[:max(room, 0)])
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
+
+// tailBuffer keeps the last limit bytes written.
+type tailBuffer struct {
+	buf   []byte
+	limit int
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.buf = append(b.buf, p...)
+	if over := len(b.buf) - b.limit; over > 0 {
+		b.buf = b.buf[over:]
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	return string(b.buf)
+}
+
+// output is the document a plugin prints.
+type output struct {
+	extractor.Document
+	Error *outputError `json:"error"`
+}
+
+type outputError struct {
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+}
+
+func (o *outputError) err(name string) error {
+	msg := o.Message
+	if msg == "" {
+		msg = "extraction failed"
+	}
+	if kind := extractor.KindByName(o.Kind); kind != nil {
+		return fmt.Errorf("plugin %s: %w: %s", name, kind, msg)
+	}
+	return fmt.Errorf("plugin %s: %s", name, msg)
+}
@@ -0,0 +1,177 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sxwebdev/downloaderbot/internal/models"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
+)
+
+// TestHelperProcess is the plugin the tests run: the test binary itself,
+// started with "--" and a link whose path picks what it does. It does nothing
+// in a normal test run.
+func TestHelperProcess(t *testing.T) {
+	args := os.Args
+	for len(args) > 0 && args[0] != "--" {
+		args = args[1:]
+	}
+	if len(args) != 2 {
+		return
+	}
+
+	u, err := url.Parse(args[1])
+	if err != nil {
+		os.Exit(2)
+	}
+
+	switch u.Path {
+	case "/video":
+		fmt.Printf(`{
+			"title": "A clip",
+			"thumbnail": "https://cdn.test/cover.jpg",
+			"items": [{
+				"type": "video",
+				"url": "https://cdn.test/720.mp4",
+				"quality": "720p",
+				"duration": 90,
+				"headers": {"Referer": %q},
+				"variants": [
+					{"url": "https://cdn.test/1080.mp4", "quality": "1080p", "height": 1080, "has_video": true},
+					{"url": "https://cdn.test/720.mp4", "quality": "720p", "height": 720, "has_video": true, "has_audio": true}
+				]
+			}]
+		}`, args[1])
+	case "/private":
+		fmt.Print(`{"error": {"kind": "private", "message": "followers only"}}`)
+		os.Exit(1)
+	case "/crash":
+		fmt.Fprint(os.Stderr, "traceback: something broke")
+		os.Exit(3)
+	case "/garbage":
+		fmt.Print("not json")
+	case "/untyped":
+		fmt.Print(`{"items": [{"url": "https://cdn.test/x"}]}`)
+	case "/slow":
+		time.Sleep(time.Minute)
+	}
+	os.Exit(0)
+}
+
+func newHelper(t *testing.T, timeout time.Duration) *Extractor {
+	t.Helper()
+	e, err := New(Config{
+		Name:            "helper",
+		Hosts:           []string{"plugin.test"},
+		Command:         []string{os.Args[0], "-test.run=^TestHelperProcess$", "--"},
+		Timeout:         timeout,
+		DownloadHeaders: true,
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return e
+}
+
+func TestExtract(t *testing.T) {
+	e := newHelper(t, 0)
+
+	link := "https://plugin.test/video"
+	media, err := e.Extract(t.Context(), link)
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if media.Source != "helper" || media.RequestUrl != link || media.Title != "A clip" || media.Url != "https://cdn.test/cover.jpg" {
+		t.Fatalf("unexpected media %+v", media)
+	}
+	if len(media.Items) != 1 {
+		t.Fatalf("got %d items, want 1", len(media.Items))
+	}
+	item := media.Items[0]
+	if item.Type != models.MediaTypeVideo || item.Url != "https://cdn.test/720.mp4" || item.Duration != 90 ||
+		item.DownloadHeaders["Referer"] != link {
+		t.Fatalf("unexpected item %+v", item)
+	}
+	if len(item.Variants) != 2 || item.Variants[0].Height != 1080 || item.Variants[0].HasAudio || !item.Variants[1].HasAudio {
+		t.Fatalf("unexpected variants %+v", item.Variants)
+	}
+}
+
+func TestExtract_Errors(t *testing.T) {
+	e := newHelper(t, 0)
+
+	t.Run("reported kind", func(t *testing.T) {
+		_, err := e.Extract(t.Context(), "https://plugin.test/private")
+		if !errors.Is(err, extractor.ErrPrivate) || !strings.Contains(err.Error(), "followers only") {
+			t.Fatalf("want ErrPrivate with the message, got %v", err)
+		}
+	})
+
+	t.Run("crash", func(t *testing.T) {
+		_, err := e.Extract(t.Context(), "https://plugin.test/crash")
+		if err == nil || extractor.Kind(err) != nil || !strings.Contains(err.Error(), "something broke") {
+			t.Fatalf("want an unexpected failure with stderr, got %v", err)
+		}
+	})
+
+	for _, path := range []string{"/garbage", "/untyped"} {
+		t.Run("invalid output "+path, func(t *testing.T) {
+			_, err := e.Extract(t.Context(), "https://plugin.test"+path)
+			if err == nil || !strings.Contains(err.Error(), "invalid output") {
+				t.Fatalf("want an invalid output error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestExtract_Kill(t *testing.T) {
+	t.Run("timeout", func(t *testing.T) {
+		start := time.Now()
+		_, err := newHelper(t, 200*time.Millisecond).Extract(t.Context(), "https://plugin.test/slow")
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("want DeadlineExceeded, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 10*time.Second {
+			t.Fatalf("the plugin was not killed, Extract took %s", elapsed)
+		}
+	})
+
+	t.Run("caller cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		time.AfterFunc(200*time.Millisecond, cancel)
+
+		start := time.Now()
+		_, err := newHelper(t, 0).Extract(ctx, "https://plugin.test/slow")
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("want Canceled, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 10*time.Second {
+			t.Fatalf("the plugin was not killed, Extract took %s", elapsed)
+		}
+	})
+}
+
+func TestRegister(t *testing.T) {
+	r := extractor.NewRegistry()
+	cfg := Config{Name: "helper", Hosts: []string{"plugin.test"}, Command: []string{"true"}, Inline: true}
+	if err := Register(r, cfg); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if chain := r.GetChainByHost("plugin.test"); len(chain) != 1 || chain[0].Name() != "helper" {
+		t.Fatalf("unexpected chain %v", chain)
+	}
+
+	cfg.Name, cfg.DownloadHeaders = "headers", true
+	if err := Register(r, cfg); err == nil {
+		t.Fatal("want an error for inline media that needs download headers")
+	}
+	if _, err := New(Config{Name: "empty", Hosts: []string{"plugin.test"}}); err == nil {
+		t.Fatal("want an error for a plugin without a command")
+	}
+}
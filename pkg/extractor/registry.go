@@ -3,31 +3,92 @@ package extractor
 import (
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 	"sync"
 
 	"github.com/sxwebdev/downloaderbot/internal/models"
 )
 
+// Priorities of the extractors registered for the same host. Higher runs
+// first; extractors of equal priority run in registration order.
+const (
+	PriorityDefault  = 0
+	PriorityFallback = -100
+)
+
+// RegisterOption configures a single Register call.
+type RegisterOption func(*registerConfig)
+
+type registerConfig struct {
+	priority int
+	catchAll bool
+}
+
+// WithPriority sets the position of the extractor in the fallback chain of
+// each of its hosts. Without it the extractor gets PriorityDefault.
+func WithPriority(priority int) RegisterOption {
+	return func(c *registerConfig) { c.priority = priority }
+}
+
+// AsCatchAll makes the extractor handle links of any host no other extractor
+// registers, exactly or by wildcard. Catch-all extractors form a chain of their
+// own, ordered by priority like host chains. They don't count as knowing a
+// host: GetChainByHost and GetSupportedHosts leave them out.
+func AsCatchAll() RegisterOption {
+	return func(c *registerConfig) { c.catchAll = true }
+}
+
 // Registry manages all registered extractors
 type Registry struct {
-	mu         sync.RWMutex
-	extractors map[string]Extractor // name -> extractor
-	hostMap    map[string]Extractor // host -> extractor
-	sources    []models.MediaSource // all registered sources
+	mu           sync.RWMutex
+	extractors   map[string]Extractor    // name -> extractor
+	capabilities map[string]Capabilities // name -> declared capabilities
+	priorities   map[string]int          // name -> priority in host chains
+	patterns     map[string][]Pattern    // name -> accepted URL shapes, none means any
+	hostMap      map[string][]Extractor  // exact host -> priority-ordered chain
+	wildcards    []*wildcardChain        // wildcard hosts, most specific first
+	catchAll     []Extractor             // chain for hosts nothing else handles
+	sources      []models.MediaSource    // all registered sources
+}
+
+// wildcardChain is the fallback chain of the extractors registering the same
+// wildcard host.
+type wildcardChain struct {
+	host  hostPattern
+	chain []Extractor
 }
 
 // NewRegistry creates a new extractor registry
 func NewRegistry() *Registry {
 	return &Registry{
-		extractors: make(map[string]Extractor),
-		hostMap:    make(map[string]Extractor),
-		sources:    make([]models.MediaSource, 0),
+		extractors:   make(map[string]Extractor),
+		capabilities: make(map[string]Capabilities),
+		priorities:   make(map[string]int),
+		patterns:     make(map[string][]Pattern),
+		hostMap:      make(map[string][]Extractor),
+		sources:      make([]models.MediaSource, 0),
 	}
 }
 
-// Register adds an extractor to the registry
-func (r *Registry) Register(ext Extractor) error {
+// Register adds an extractor to the registry. The extractor's capabilities
+// are validated up front, so a broken declaration fails at startup rather than
+// on the first user request.
+//
+// Several extractors may claim the same host: they form a fallback chain
+// ordered by priority (see WithPriority), which GetChainByURL returns.
+//
+// Hosts may be wildcards: "*.tumblr.com" for any subdomain and "instagram.*"
+// for any top-level or country domain. A link's host is looked up exactly
+// first; only when no extractor registers it exactly is the most specific
+// matching wildcard used (see hostPattern.moreSpecific). Chains never mix
+// extractors of different hosts.
+func (r *Registry) Register(ext Extractor, opts ...RegisterOption) error {
+	cfg := registerConfig{priority: PriorityDefault}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -36,21 +97,92 @@ func (r *Registry) Register(ext Extractor) error {
 		return fmt.Errorf("extractor %s already registered", name)
 	}
 
+	caps := CapabilitiesOf(ext)
+	if err := caps.Validate(); err != nil {
+		return fmt.Errorf("extractor %s has invalid capabilities: %w", name, err)
+	}
+
+	// Normalize hosts (remove www. prefix for matching) and validate them all
+	// before touching the maps, so a rejected extractor leaves no trace.
+	hosts := make([]hostPattern, 0, len(ext.Hosts()))
+	for _, host := range ext.Hosts() {
+		parsed, err := parseHostPattern(host)
+		if err != nil {
+			return fmt.Errorf("extractor %s: %w", name, err)
+		}
+		if slices.ContainsFunc(hosts, func(h hostPattern) bool { return h.raw == parsed.raw }) {
+			return fmt.Errorf("host %s listed twice by extractor %s", host, name)
+		}
+		hosts = append(hosts, parsed)
+	}
+
+	var patterns []Pattern
+	if p, ok := ext.(PatternProvider); ok {
+		patterns = p.Patterns()
+	}
+	for _, pattern := range patterns {
+		if (pattern.Path == nil) == (pattern.Match == nil) {
+			return fmt.Errorf("extractor %s has a URL pattern with both or neither of path and match", name)
+		}
+		if pattern.Host != "" && !slices.ContainsFunc(hosts, func(h hostPattern) bool { return h.raw == normalizeHost(pattern.Host) }) {
+			return fmt.Errorf("extractor %s has a URL pattern for host %s it does not register", name, pattern.Host)
+		}
+	}
+
 	r.extractors[name] = ext
+	r.capabilities[name] = caps
+	r.priorities[name] = cfg.priority
+	r.patterns[name] = patterns
 	r.sources = append(r.sources, models.MediaSource(name))
 
-	for _, host := range ext.Hosts() {
-		// Normalize host (remove www. prefix for matching)
-		normalizedHost := normalizeHost(host)
-		if _, exists := r.hostMap[normalizedHost]; exists {
-			return fmt.Errorf("host %s already registered", host)
+	if cfg.catchAll {
+		r.catchAll = r.insertByPriority(r.catchAll, ext, cfg.priority)
+	}
+
+	for _, host := range hosts {
+		if host.kind == hostExact {
+			r.hostMap[host.raw] = r.insertByPriority(r.hostMap[host.raw], ext, cfg.priority)
+			continue
 		}
-		r.hostMap[normalizedHost] = ext
+		r.addWildcard(host, ext, cfg.priority)
 	}
 
 	return nil
 }
 
+// addWildcard adds ext to the chain of a wildcard host, creating the chain in
+// its precedence slot if it is new.
+func (r *Registry) addWildcard(host hostPattern, ext Extractor, priority int) {
+	for _, w := range r.wildcards {
+		if w.host.raw == host.raw {
+			w.chain = r.insertByPriority(w.chain, ext, priority)
+			return
+		}
+	}
+
+	idx := len(r.wildcards)
+	for i, w := range r.wildcards {
+		if host.moreSpecific(w.host) {
+			idx = i
+			break
+		}
+	}
+	r.wildcards = slices.Insert(r.wildcards, idx, &wildcardChain{host: host, chain: []Extractor{ext}})
+}
+
+// insertByPriority places ext into chain after every extractor of the same or
+// higher priority, keeping registration order among equals.
+func (r *Registry) insertByPriority(chain []Extractor, ext Extractor, priority int) []Extractor {
+	idx := len(chain)
+	for i, cur := range chain {
+		if r.priorities[cur.Name()] < priority {
+			idx = i
+			break
+		}
+	}
+	return slices.Insert(chain, idx, ext)
+}
+
 // GetByName returns an extractor by its name
 func (r *Registry) GetByName(name string) (Extractor, bool) {
 	r.mu.RLock()
@@ -60,29 +192,101 @@ func (r *Registry) GetByName(name string) (Extractor, bool) {
 	return ext, ok
 }
 
-// GetByHost returns an extractor that handles the given host
+// GetCapabilities returns the capabilities declared by the named extractor
+func (r *Registry) GetCapabilities(name string) (Capabilities, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	caps, ok := r.capabilities[name]
+	return caps, ok
+}
+
+// GetByHost returns the highest-priority extractor that handles the given host
 func (r *Registry) GetByHost(host string) (Extractor, bool) {
+	chain := r.GetChainByHost(host)
+	if len(chain) == 0 {
+		return nil, false
+	}
+	return chain[0], true
+}
+
+// GetChainByHost returns every extractor that handles the given host, in the
+// order they should be tried. Exact registrations take precedence over
+// wildcards.
+func (r *Registry) GetChainByHost(host string) []Extractor {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	normalizedHost := normalizeHost(host)
-	ext, ok := r.hostMap[normalizedHost]
-	return ext, ok
+	if chain, ok := r.hostMap[normalizedHost]; ok {
+		return slices.Clone(chain)
+	}
+	for _, w := range r.wildcards {
+		if w.host.match(normalizedHost) {
+			return slices.Clone(w.chain)
+		}
+	}
+	return nil
 }
 
 // GetByURL parses the URL and returns the appropriate extractor
 func (r *Registry) GetByURL(rawURL string) (Extractor, error) {
+	chain, err := r.GetChainByURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return chain[0], nil
+}
+
+// GetChainByURL parses the URL and returns the priority-ordered fallback chain
+// of extractors for it, which is the catch-all chain for hosts no extractor
+// registers. Extractors whose URL patterns don't match are left out; when that
+// leaves none, the error wraps ErrUnsupportedURL. The chain is never empty when
+// err is nil.
+func (r *Registry) GetChainByURL(rawURL string) ([]Extractor, error) {
 	parsedURL, err := url.Parse(rawURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse URL: %w", err)
 	}
 
-	ext, ok := r.GetByHost(parsedURL.Host)
-	if !ok {
+	chain := r.GetChainByHost(parsedURL.Host)
+	if len(chain) == 0 {
+		chain = r.getCatchAll()
+	}
+	if len(chain) == 0 {
 		return nil, fmt.Errorf("no extractor found for host: %s", parsedURL.Host)
 	}
 
-	return ext, nil
+	host := normalizeHost(parsedURL.Host)
+	chain = slices.DeleteFunc(chain, func(ext Extractor) bool {
+		return !r.accepts(ext, host, parsedURL)
+	})
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedURL, rawURL)
+	}
+
+	return chain, nil
+}
+
+func (r *Registry) getCatchAll() []Extractor {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.catchAll)
+}
+
+// accepts reports whether ext handles u according to its URL patterns.
+func (r *Registry) accepts(ext Extractor, host string, u *url.URL) bool {
+	r.mu.RLock()
+	patterns := r.patterns[ext.Name()]
+	r.mu.RUnlock()
+
+	if len(patterns) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(patterns, func(p Pattern) bool {
+		return p.matches(host, u)
+	})
 }
 
 // GetSupportedSources returns all registered media sources
@@ -112,10 +316,13 @@ func (r *Registry) GetSupportedHosts() []string {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	hosts := make([]string, 0, len(r.hostMap))
+	hosts := make([]string, 0, len(r.hostMap)+len(r.wildcards))
 	for host := range r.hostMap {
 		hosts = append(hosts, host)
 	}
+	for _, w := range r.wildcards {
+		hosts = append(hosts, w.host.raw)
+	}
 	return hosts
 }
 
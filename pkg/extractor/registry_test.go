@@ -2,6 +2,11 @@ package extractor
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
 	"strings"
 	"testing"
 
@@ -20,8 +25,8 @@ func (f *fakeExtractor) Extract(context.Context, string) (*models.Media, error)
 func TestRegistry_RegisterAndGet(t *testing.T) {
 	r := NewRegistry()
 
-	// `www.` prefix would normalize to the same host — registry rejects duplicates,
-	// so only the canonical form is listed here.
+	// `www.` prefix would normalize to the same host — registry rejects an
+	// extractor listing it twice, so only the canonical form is listed here.
 	a := &fakeExtractor{name: "site-a", hosts: []string{"a.test"}}
 	b := &fakeExtractor{name: "site-b", hosts: []string{"b.test"}}
 
@@ -58,9 +63,133 @@ func TestRegistry_RegisterDuplicate(t *testing.T) {
 		t.Fatalf("want duplicate error, got %v", err)
 	}
 
-	err = r.Register(&fakeExtractor{name: "y", hosts: []string{"x.test"}})
-	if err == nil || !strings.Contains(err.Error(), "already registered") {
-		t.Fatalf("want host duplicate error, got %v", err)
+	// The same extractor can't claim a host twice, not even through a prefix
+	// that normalizes away.
+	err = r.Register(&fakeExtractor{name: "y", hosts: []string{"y.test", "www.y.test"}})
+	if err == nil || !strings.Contains(err.Error(), "listed twice") {
+		t.Fatalf("want host listed twice error, got %v", err)
+	}
+	if _, ok := r.GetByName("y"); ok {
+		t.Fatal("rejected extractor must not be registered")
+	}
+	if _, ok := r.GetByHost("y.test"); ok {
+		t.Fatal("rejected extractor must not claim its hosts")
+	}
+}
+
+func TestRegistry_FallbackChain(t *testing.T) {
+	r := NewRegistry()
+
+	// Registration order deliberately differs from the priority order.
+	fallback := &fakeExtractor{name: "lux-x", hosts: []string{"x.test"}}
+	custom := &fakeExtractor{name: "x", hosts: []string{"x.test", "short.x.test"}}
+	second := &fakeExtractor{name: "x2", hosts: []string{"x.test"}}
+
+	if err := r.Register(fallback, WithPriority(PriorityFallback)); err != nil {
+		t.Fatalf("register fallback: %v", err)
+	}
+	if err := r.Register(custom); err != nil {
+		t.Fatalf("register custom: %v", err)
+	}
+	if err := r.Register(second); err != nil {
+		t.Fatalf("register second: %v", err)
+	}
+
+	chain, err := r.GetChainByURL("https://www.x.test/video/1")
+	if err != nil {
+		t.Fatalf("GetChainByURL: %v", err)
+	}
+	got := make([]string, 0, len(chain))
+	for _, ext := range chain {
+		got = append(got, ext.Name())
+	}
+	// Higher priority first, registration order among equals.
+	if want := []string{"x", "x2", "lux-x"}; !slices.Equal(got, want) {
+		t.Fatalf("chain = %v, want %v", got, want)
+	}
+
+	ext, err := r.GetByURL("https://x.test/video/1")
+	if err != nil {
+		t.Fatalf("GetByURL: %v", err)
+	}
+	if ext.Name() != "x" {
+		t.Fatalf("GetByURL = %q, want the chain head x", ext.Name())
+	}
+
+	// Hosts claimed by a single extractor have a chain of one.
+	if chain := r.GetChainByHost("short.x.test"); len(chain) != 1 || chain[0].Name() != "x" {
+		t.Fatalf("short host chain = %v, want [x]", chain)
+	}
+}
+
+func TestShouldFallThrough(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"not supported", ErrNotSupported, true},
+		{"blocked wrapped", fmt.Errorf("tiktok: %w: captcha", ErrBlocked), true},
+		{"rate limited", fmt.Errorf("instagram: %w", ErrRateLimited), true},
+		{"upstream changed", fmt.Errorf("instagram: %w", ErrUpstreamChanged), true},
+		{"private", fmt.Errorf("instagram: %w", ErrPrivate), false},
+		{"not found", ErrNotFound, false},
+		{"other error", errors.New("post is private"), false},
+		{"timeout", context.DeadlineExceeded, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ShouldFallThrough(tc.err); got != tc.want {
+				t.Fatalf("ShouldFallThrough(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestKind(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"direct", ErrGeoBlocked, ErrGeoBlocked},
+		{"wrapped", fmt.Errorf("youtube: %w: %w", ErrLoginRequired, errors.New("age check")), ErrLoginRequired},
+		// The more specific kind wins when an error wraps two.
+		{"joined", errors.Join(fmt.Errorf("a: %w", ErrBlocked), fmt.Errorf("b: %w", ErrPrivate)), ErrPrivate},
+		{"untyped", errors.New("boom"), nil},
+		{"nil", nil, nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Kind(tc.err); got != tc.want {
+				t.Fatalf("Kind(%v) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	tests := map[int]error{
+		404: ErrNotFound,
+		410: ErrNotFound,
+		401: ErrLoginRequired,
+		403: ErrBlocked,
+		429: ErrRateLimited,
+		451: ErrGeoBlocked,
+		500: nil,
+		400: nil,
+	}
+	for status, want := range tests {
+		err := StatusError(status)
+		if want == nil {
+			if err != nil {
+				t.Errorf("StatusError(%d) = %v, want nil", status, err)
+			}
+			continue
+		}
+		if !errors.Is(err, want) {
+			t.Errorf("StatusError(%d) = %v, want %v", status, err, want)
+		}
 	}
 }
 
@@ -84,3 +213,298 @@ func TestNormalizeHost(t *testing.T) {
 		}
 	}
 }
+
+type capsExtractor struct {
+	fakeExtractor
+	caps Capabilities
+}
+
+func (c *capsExtractor) Capabilities() Capabilities { return c.caps }
+
+func TestRegistry_Capabilities(t *testing.T) {
+	r := NewRegistry()
+
+	formats := Capabilities{
+		MediaTypes: []models.MediaType{models.MediaTypeVideo, models.MediaTypeAudio},
+		Output:     OutputFormats,
+	}
+	if err := r.Register(&capsExtractor{fakeExtractor{name: "formats", hosts: []string{"f.test"}}, formats}); err != nil {
+		t.Fatalf("register formats: %v", err)
+	}
+	if err := r.Register(&fakeExtractor{name: "plain", hosts: []string{"p.test"}}); err != nil {
+		t.Fatalf("register plain: %v", err)
+	}
+
+	caps, ok := r.GetCapabilities("formats")
+	if !ok {
+		t.Fatal("expected capabilities for formats")
+	}
+	if caps.Inline || caps.Output != OutputFormats || !caps.Produces(models.MediaTypeAudio) {
+		t.Fatalf("got %+v, want the declared capabilities", caps)
+	}
+
+	// Extractors without a declaration get the defaults.
+	caps, ok = r.GetCapabilities("plain")
+	if !ok {
+		t.Fatal("expected capabilities for plain")
+	}
+	if !caps.Inline || caps.Output != OutputItems || caps.Produces(models.MediaTypeAudio) {
+		t.Fatalf("got %+v, want DefaultCapabilities", caps)
+	}
+}
+
+func TestRegistry_RegisterInvalidCapabilities(t *testing.T) {
+	tests := []struct {
+		name string
+		caps Capabilities
+	}{
+		{"unknown output", Capabilities{MediaTypes: []models.MediaType{models.MediaTypeVideo}, Output: "stream"}},
+		{"no media types", Capabilities{Output: OutputItems}},
+		{"unknown media type", Capabilities{MediaTypes: []models.MediaType{"gif"}, Output: OutputItems}},
+		{
+			// Telegram fetches inline results without headers.
+			"inline with download headers",
+			Capabilities{Inline: true, DownloadHeaders: true, MediaTypes: []models.MediaType{models.MediaTypeVideo}, Output: OutputItems},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewRegistry()
+			err := r.Register(&capsExtractor{fakeExtractor{name: "x", hosts: []string{"x.test"}}, tc.caps})
+			if err == nil || !strings.Contains(err.Error(), "invalid capabilities") {
+				t.Fatalf("want invalid capabilities error, got %v", err)
+			}
+			if _, ok := r.GetByName("x"); ok {
+				t.Fatal("rejected extractor must not be registered")
+			}
+		})
+	}
+}
+
+type patternExtractor struct {
+	fakeExtractor
+	patterns []Pattern
+}
+
+func (p *patternExtractor) Patterns() []Pattern { return p.patterns }
+
+func TestRegistry_Patterns(t *testing.T) {
+	r := NewRegistry()
+
+	posts := &patternExtractor{
+		fakeExtractor{name: "posts", hosts: []string{"x.test", "short.test"}},
+		[]Pattern{
+			{Host: "x.test", Path: regexp.MustCompile(`^/p/\w+`)},
+			{Host: "short.test", Match: func(u *url.URL) bool { return u.Query().Has("id") }},
+		},
+	}
+	if err := r.Register(posts); err != nil {
+		t.Fatalf("register posts: %v", err)
+	}
+
+	for _, link := range []string{"https://x.test/p/abc", "https://www.x.test/p/abc?x=1", "https://short.test/?id=1"} {
+		ext, err := r.GetByURL(link)
+		if err != nil {
+			t.Fatalf("%s: %v", link, err)
+		}
+		if ext.Name() != "posts" {
+			t.Fatalf("%s: got %s, want posts", link, ext.Name())
+		}
+	}
+
+	// Patterns are scoped to their host.
+	for _, link := range []string{"https://x.test/user", "https://x.test/?id=1", "https://short.test/p/abc"} {
+		_, err := r.GetByURL(link)
+		if !errors.Is(err, ErrUnsupportedURL) {
+			t.Fatalf("%s: want ErrUnsupportedURL, got %v", link, err)
+		}
+	}
+
+	// An extractor without patterns accepts the links the others reject, so
+	// the chain only holds it.
+	if err := r.Register(&fakeExtractor{name: "any", hosts: []string{"x.test"}}, WithPriority(PriorityFallback)); err != nil {
+		t.Fatalf("register any: %v", err)
+	}
+	chain, err := r.GetChainByURL("https://x.test/user")
+	if err != nil {
+		t.Fatalf("get chain: %v", err)
+	}
+	if len(chain) != 1 || chain[0].Name() != "any" {
+		t.Fatalf("got chain of %d extractors, want only any", len(chain))
+	}
+}
+
+func TestRegistry_RegisterInvalidPatterns(t *testing.T) {
+	re := regexp.MustCompile(`^/`)
+	match := func(*url.URL) bool { return true }
+
+	tests := []struct {
+		name    string
+		pattern Pattern
+	}{
+		{"neither path nor match", Pattern{Host: "x.test"}},
+		{"both path and match", Pattern{Path: re, Match: match}},
+		{"foreign host", Pattern{Host: "y.test", Path: re}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewRegistry()
+			err := r.Register(&patternExtractor{fakeExtractor{name: "x", hosts: []string{"x.test"}}, []Pattern{tc.pattern}})
+			if err == nil {
+				t.Fatal("want error for invalid pattern")
+			}
+			if _, ok := r.GetByName("x"); ok {
+				t.Fatal("rejected extractor must not be registered")
+			}
+		})
+	}
+}
+
+func TestRegistry_WildcardHosts(t *testing.T) {
+	r := NewRegistry()
+
+	register := func(name string, hosts ...string) {
+		t.Helper()
+		if err := r.Register(&fakeExtractor{name: name, hosts: hosts}); err != nil {
+			t.Fatalf("register %s: %v", name, err)
+		}
+	}
+	register("blogs", "*.blog.test")
+	register("blog", "blog.test")
+	register("music", "music.blog.test")
+	register("deep", "*.eu.blog.test")
+	register("country", "blog.*")
+	register("shop", "shop.co.*")
+
+	tests := []struct {
+		host string
+		want string
+	}{
+		// The apex is not covered by its suffix wildcard.
+		{"blog.test", "blog"},
+		{"someone.blog.test", "blogs"},
+		{"www.someone.blog.test", "blogs"},
+		// Exact registrations beat wildcards.
+		{"music.blog.test", "music"},
+		{"m.music.blog.test", "music"},
+		// The wildcard with more fixed labels wins.
+		{"someone.eu.blog.test", "deep"},
+		{"eu.blog.test", "blogs"},
+		{"blog.com.br", "country"},
+		{"blog.co.blog.test", "blogs"},
+		// Suffix beats TLD wildcards of the same specificity.
+		{"shop.co.uk", "shop"},
+		{"shop.co.blog.test", "blogs"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.host, func(t *testing.T) {
+			ext, ok := r.GetByHost(tc.host)
+			if !ok {
+				t.Fatalf("no extractor for %s", tc.host)
+			}
+			if ext.Name() != tc.want {
+				t.Fatalf("got %s, want %s", ext.Name(), tc.want)
+			}
+		})
+	}
+
+	// A TLD wildcard only covers real top-level domains, not any domain under
+	// the base label.
+	notTLD := []string{
+		"blog.evil.example", "blog.com.attacker.net", "blog.com.br.attacker.net",
+		"blog.github.io", "blog.co.uk", "blog.com.invalidtld", "blog.",
+		"shop.co.evil.example",
+	}
+	for _, host := range append([]string{"blogtest", "blog", "other.test", "notblog.test"}, notTLD...) {
+		if ext, ok := r.GetByHost(host); ok {
+			t.Fatalf("%s: unexpected extractor %s", host, ext.Name())
+		}
+	}
+}
+
+func TestRegistry_WildcardChain(t *testing.T) {
+	r := NewRegistry()
+
+	if err := r.Register(&fakeExtractor{name: "backup", hosts: []string{"*.x.test"}}, WithPriority(PriorityFallback)); err != nil {
+		t.Fatalf("register backup: %v", err)
+	}
+	if err := r.Register(&fakeExtractor{name: "main", hosts: []string{"*.X.test"}}); err != nil {
+		t.Fatalf("register main: %v", err)
+	}
+	// A narrower wildcard is a chain of its own, not merged with the broader one.
+	if err := r.Register(&fakeExtractor{name: "narrow", hosts: []string{"*.a.x.test"}}); err != nil {
+		t.Fatalf("register narrow: %v", err)
+	}
+
+	names := func(chain []Extractor) []string {
+		var out []string
+		for _, ext := range chain {
+			out = append(out, ext.Name())
+		}
+		return out
+	}
+
+	if got, want := names(r.GetChainByHost("b.x.test")), []string{"main", "backup"}; !slices.Equal(got, want) {
+		t.Fatalf("b.x.test chain = %v, want %v", got, want)
+	}
+	if got, want := names(r.GetChainByHost("b.a.x.test")), []string{"narrow"}; !slices.Equal(got, want) {
+		t.Fatalf("b.a.x.test chain = %v, want %v", got, want)
+	}
+	if !slices.Contains(r.GetSupportedHosts(), "*.x.test") {
+		t.Fatalf("supported hosts %v miss the wildcard", r.GetSupportedHosts())
+	}
+}
+
+func TestRegistry_RegisterInvalidHosts(t *testing.T) {
+	for _, host := range []string{"*.com", "*", "a.*.test", "*.*.test", "x.test.*.*", ".*"} {
+		t.Run(host, func(t *testing.T) {
+			r := NewRegistry()
+			if err := r.Register(&fakeExtractor{name: "x", hosts: []string{host}}); err == nil {
+				t.Fatalf("want error for host %s", host)
+			}
+		})
+	}
+}
+
+func TestRegistry_CatchAll(t *testing.T) {
+	r := NewRegistry()
+
+	if _, err := r.GetByURL("https://unknown.test/page"); err == nil {
+		t.Fatal("expected error without a catch-all extractor")
+	}
+
+	if err := r.Register(&fakeExtractor{name: "generic"}, AsCatchAll()); err != nil {
+		t.Fatalf("register generic: %v", err)
+	}
+	if err := r.Register(&fakeExtractor{name: "site", hosts: []string{"site.test"}}); err != nil {
+		t.Fatalf("register site: %v", err)
+	}
+
+	ext, err := r.GetByURL("https://unknown.test/page")
+	if err != nil {
+		t.Fatalf("get unknown host: %v", err)
+	}
+	if ext.Name() != "generic" {
+		t.Fatalf("got %s, want generic", ext.Name())
+	}
+
+	// Hosts with an extractor never reach the catch-all.
+	chain, err := r.GetChainByURL("https://site.test/page")
+	if err != nil {
+		t.Fatalf("get site: %v", err)
+	}
+	if len(chain) != 1 || chain[0].Name() != "site" {
+		t.Fatalf("got chain of %d extractors, want only site", len(chain))
+	}
+
+	// The catch-all doesn't make a host known.
+	if _, ok := r.GetByHost("unknown.test"); ok {
+		t.Fatal("catch-all must not be returned by host")
+	}
+	if slices.Contains(r.GetSupportedHosts(), "") {
+		t.Fatal("catch-all must not add a supported host")
+	}
+}
@@ -0,0 +1,217 @@
+package script
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/dop251/goja"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
+)
+
+// host is the API of one run, bound to its runtime and context.
+type host struct {
+	ctx     context.Context
+	vm      *goja.Runtime
+	client  *http.Client
+	browser pageLoader
+	// budget is how many more bytes the host API may hand to the script.
+	budget  int64
+	regexps map[string]*regexp.Regexp
+}
+
+// install defines the host API in the runtime.
+func (h *host) install() error {
+	h.regexps = make(map[string]*regexp.Regexp)
+
+	browser := h.vm.NewObject()
+	regex := h.vm.NewObject()
+	for _, def := range []struct {
+		obj  *goja.Object
+		name string
+		fn   any
+	}{
+		{browser, "load", h.load},
+		{regex, "match", h.match},
+		{regex, "matchAll", h.matchAll},
+		{regex, "replace", h.replace},
+	} {
+		if err := def.obj.Set(def.name, def.fn); err != nil {
+			return err
+		}
+	}
+
+	for name, value := range map[string]any{
+		"fetch":   h.fetch,
+		"fail":    h.fail,
+		"browser": browser,
+		"regex":   regex,
+	} {
+		if err := h.vm.Set(name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// throw aborts the current call with err as a JavaScript exception.
+func (h *host) throw(err error) {
+	panic(h.vm.NewGoError(err))
+}
+
+// charge takes n bytes from the budget. Running out stops the run, even if
+// the script catches the exception.
+func (h *host) charge(n int64) {
+	h.budget -= n
+	if h.budget < 0 {
+		h.vm.Interrupt(ErrHostBytesLimit)
+		h.throw(ErrHostBytesLimit)
+	}
+}
+
+type fetchOptions struct {
+	Method  string            `json:"method"`
+	Headers map[string]string `json:"headers"`
+	Body    string            `json:"body"`
+}
+
+func (h *host) fetch(url string, opts *fetchOptions) map[string]any {
+	if opts == nil {
+		opts = &fetchOptions{}
+	}
+	method := opts.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	var body io.Reader
+	if opts.Body != "" {
+		body = strings.NewReader(opts.Body)
+	}
+	req, err := http.NewRequestWithContext(h.ctx, strings.ToUpper(method), url, body)
+	if err != nil {
+		h.throw(err)
+	}
+	for k, v := range opts.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		h.throw(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, max(h.budget, 0)+1))
+	if err != nil {
+		h.throw(err)
+	}
+	h.charge(int64(len(data)))
+	text := string(data)
+
+	headers := make(map[string]any, len(resp.Header))
+	for k, v := range resp.Header {
+		headers[strings.ToLower(k)] = strings.Join(v, ", ")
+	}
+
+	return map[string]any{
+		"status":  resp.StatusCode,
+		"url":     resp.Request.URL.String(),
+		"headers": headers,
+		"text":    text,
+		"json": func() goja.Value {
+			// The parsed objects take at least as much as the text.
+			h.charge(int64(len(text)))
+			return h.parseJSON(text)
+		},
+	}
+}
+
+// parseJSON parses text with the JSON.parse of the runtime, so the result is
+// an ordinary script object.
+func (h *host) parseJSON(text string) goja.Value {
+	parse, ok := goja.AssertFunction(h.vm.Get("JSON").ToObject(h.vm).Get("parse"))
+	if !ok {
+		h.throw(fmt.Errorf("JSON.parse is not a function"))
+	}
+	v, err := parse(goja.Undefined(), h.vm.ToValue(text))
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (h *host) load(url string) map[string]any {
+	res, err := h.browser.Load(h.ctx, url)
+	if err != nil {
+		h.throw(err)
+	}
+	h.charge(int64(len(res.HTML)))
+
+	return map[string]any{
+		"url":     res.FinalURL,
+		"html":    res.HTML,
+		"cookies": res.CookieHeader(),
+	}
+}
+
+func (h *host) compile(pattern string) *regexp.Regexp {
+	if re, ok := h.regexps[pattern]; ok {
+		return re
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		h.throw(err)
+	}
+	h.regexps[pattern] = re
+	return re
+}
+
+func (h *host) match(pattern, text string) goja.Value {
+	m := h.compile(pattern).FindStringSubmatch(text)
+	if m == nil {
+		return goja.Null()
+	}
+	h.chargeStrings(m)
+	return h.vm.ToValue(m)
+}
+
+func (h *host) matchAll(pattern, text string) [][]string {
+	matches := h.compile(pattern).FindAllStringSubmatch(text, -1)
+	if matches == nil {
+		return [][]string{}
+	}
+	for _, m := range matches {
+		h.chargeStrings(m)
+	}
+	return matches
+}
+
+func (h *host) replace(pattern, text, replacement string) string {
+	s := h.compile(pattern).ReplaceAllString(text, replacement)
+	h.charge(int64(len(s)))
+	return s
+}
+
+// chargeStrings charges the strings handed to the script, which become copies
+// of their own in the runtime.
+func (h *host) chargeStrings(ss []string) {
+	var n int
+	for _, s := range ss {
+		n += len(s)
+	}
+	h.charge(int64(n))
+}
+
+func (h *host) fail(kind, message string) {
+	if message == "" {
+		message = "extraction failed"
+	}
+	if err := extractor.KindByName(kind); err != nil {
+		h.throw(fmt.Errorf("%w: %s", err, message))
+	}
+	h.throw(fmt.Errorf("%s", message))
+}
@@ -0,0 +1,222 @@
+// Package script runs extractors written in JavaScript, for sites that need a
+// bit of logic on top of fetching a page: decoding an obfuscated URL,
+// combining two API calls. Scripts run in goja, an embedded JavaScript engine
+// without access to the file system or the network other than through the
+// host API below.
+//
+// A script defines a global function extract(url) returning the media as an
+// object of the shape plugins print (see pkg/extractor/plugin):
+//
+//	function extract(url) {
+//	  const id = regex.match("/v/(\\w+)", url)[1];
+//	  const info = fetch("https://api.example.com/v/" + id).json();
+//	  return {title: info.title, items: [{type: "video", url: info.src}]};
+//	}
+//
+// The host API:
+//
+//	fetch(url, {method, headers, body}) -> {status, url, headers, text, json()}
+//	browser.load(url)                   -> {url, html, cookies}
+//	regex.match(pattern, text)          -> groups of the first match, or null
+//	regex.matchAll(pattern, text)       -> groups of every match
+//	regex.replace(pattern, text, repl)  -> text with matches replaced ($1 for groups)
+//	fail(kind, message)                 -> throws an extraction error
+//
+// fetch goes through the shared HTTP client and doesn't throw on an
+// unsuccessful status. regex uses Go regular expressions, which run in linear
+// time unlike the JavaScript RegExp. The kinds fail takes are those of
+// extractor.KindByName. JSON and the rest of the ECMAScript standard library
+// are available as usual.
+//
+// A run is stopped when it takes longer than its timeout, or once the host API
+// has handed it more than Config.MaxHostBytes: response bodies, page HTML,
+// parsed JSON and regex results. Scripts have no memory limit: goja doesn't
+// account the memory of a runtime, so what a script allocates on its own, like
+// an array grown in a loop, is only bounded by the timeout and can exhaust the
+// process. Run trusted scripts only.
+package script
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/sxwebdev/downloaderbot/internal/models"
+	"github.com/sxwebdev/downloaderbot/internal/util"
+	"github.com/sxwebdev/downloaderbot/pkg/browser"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
+)
+
+const (
+	// DefaultTimeout bounds a run when the config sets no timeout.
+	DefaultTimeout = 30 * time.Second
+	// DefaultMaxHostBytes bounds what the host API hands to a run when the
+	// config sets no limit.
+	DefaultMaxHostBytes = 64 << 20
+)
+
+// maxCallStackSize stops runaway recursion long before it costs memory.
+const maxCallStackSize = 1024
+
+// ErrHostBytesLimit is returned when the host API has handed a run more than
+// its limit.
+var ErrHostBytesLimit = errors.New("script host data limit exceeded")
+
+// Config describes a script extractor.
+type Config struct {
+	// Name is the extractor name, also reported as the media source.
+	Name string
+	// Hosts are the hosts the script handles, wildcards included.
+	Hosts []string
+	// Source is the script.
+	Source string
+	// Timeout bounds a run, DefaultTimeout when zero.
+	Timeout time.Duration
+	// MaxHostBytes bounds what the host API hands to a run, in bytes,
+	// DefaultMaxHostBytes when zero. It is not a memory limit.
+	MaxHostBytes int64
+	// Priority places the script in the chains of its hosts (see
+	// extractor.WithPriority).
+	Priority int
+	// Inline allows to offer the media in Telegram inline mode.
+	Inline bool
+	// DownloadHeaders declares that the media URLs need the item headers.
+	DownloadHeaders bool
+	// Formats declares the variants of the items as formats to choose from
+	// (see extractor.OutputFormats).
+	Formats bool
+}
+
+// pageLoader is the part of browser.Manager scripts use.
+type pageLoader interface {
+	Load(ctx context.Context, url string, opts ...browser.LoadOption) (*browser.Result, error)
+}
+
+// Extractor implements the extractor.Extractor interface by running a script.
+type Extractor struct {
+	cfg     Config
+	program *goja.Program
+	browser pageLoader
+}
+
+// New compiles the script of a script extractor.
+func New(cfg Config) (*Extractor, error) {
+	if cfg.Name == "" {
+		return nil, errors.New("script name is empty")
+	}
+	if len(cfg.Hosts) == 0 {
+		return nil, fmt.Errorf("script %s has no hosts", cfg.Name)
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.MaxHostBytes <= 0 {
+		cfg.MaxHostBytes = DefaultMaxHostBytes
+	}
+
+	program, err := goja.Compile(cfg.Name, cfg.Source, true)
+	if err != nil {
+		return nil, fmt.Errorf("script %s: %w", cfg.Name, err)
+	}
+
+	return &Extractor{cfg: cfg, program: program, browser: browser.Default()}, nil
+}
+
+// Register compiles the script and adds the extractor to the registry.
+func Register(r *extractor.Registry, cfg Config) error {
+	e, err := New(cfg)
+	if err != nil {
+		return err
+	}
+	return r.Register(e, extractor.WithPriority(cfg.Priority))
+}
+
+// Name returns the extractor name.
+func (e *Extractor) Name() string {
+	return e.cfg.Name
+}
+
+// Hosts returns the configured hosts.
+func (e *Extractor) Hosts() []string {
+	return e.cfg.Hosts
+}
+
+// Capabilities declares what the config says about the script output.
+func (e *Extractor) Capabilities() extractor.Capabilities {
+	output := extractor.OutputItems
+	if e.cfg.Formats {
+		output = extractor.OutputFormats
+	}
+	return extractor.Capabilities{
+		Inline:          e.cfg.Inline,
+		DownloadHeaders: e.cfg.DownloadHeaders,
+		MediaTypes:      []models.MediaType{models.MediaTypeVideo, models.MediaTypePhoto, models.MediaTypeAudio},
+		Output:          output,
+	}
+}
+
+// Extract runs the script for the link in a runtime of its own.
+func (e *Extractor) Extract(ctx context.Context, url string) (*models.Media, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.cfg.Timeout)
+	defer cancel()
+
+	vm := goja.New()
+	vm.SetMaxCallStackSize(maxCallStackSize)
+	vm.SetFieldNameMapper(goja.TagFieldNameMapper("json", true))
+
+	h := &host{
+		ctx:     ctx,
+		vm:      vm,
+		client:  util.DefaultHttpClient(),
+		browser: e.browser,
+		budget:  e.cfg.MaxHostBytes,
+	}
+	if err := h.install(); err != nil {
+		return nil, fmt.Errorf("script %s: %w", e.cfg.Name, err)
+	}
+
+	stop := context.AfterFunc(ctx, func() { vm.Interrupt(ctx.Err()) })
+	defer stop()
+
+	result, err := e.run(vm, url)
+	if err != nil {
+		return nil, fmt.Errorf("script %s: %w", e.cfg.Name, err)
+	}
+
+	media, err := result.Media(models.MediaSource(e.cfg.Name), url)
+	if err != nil {
+		return nil, fmt.Errorf("script %s: invalid result: %w", e.cfg.Name, err)
+	}
+	return media, nil
+}
+
+// run evaluates the script and calls its extract function.
+func (e *Extractor) run(vm *goja.Runtime, url string) (*extractor.Document, error) {
+	if _, err := vm.RunProgram(e.program); err != nil {
+		return nil, err
+	}
+	extract, ok := goja.AssertFunction(vm.Get("extract"))
+	if !ok {
+		return nil, errors.New("the script defines no extract function")
+	}
+
+	value, err := extract(goja.Undefined(), vm.ToValue(url))
+	if err != nil {
+		return nil, err
+	}
+
+	// The result goes through JSON, so that it is read exactly like plugin
+	// output.
+	data, err := json.Marshal(value.Export())
+	if err != nil {
+		return nil, fmt.Errorf("invalid result: %w", err)
+	}
+	var doc extractor.Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid result: %w", err)
+	}
+	return &doc, nil
+}
@@ -0,0 +1,190 @@
+package script
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sxwebdev/downloaderbot/internal/models"
+	"github.com/sxwebdev/downloaderbot/pkg/browser"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
+)
+
+const clipScript = `
+function extract(url) {
+  const id = regex.match("/v/(\\w+)$", url)[1];
+  const res = fetch(api + "/clips/" + id, {headers: {"Accept": "application/json"}});
+  if (res.status === 404) {
+    fail("not_found", "no clip " + id);
+  }
+  const info = res.json();
+  return {
+    title: info.title,
+    thumbnail: regex.replace("^http:", info.cover, "https:"),
+    items: info.files.map(f => ({type: "video", url: f.src, height: f.height, quality: f.height + "p"})),
+  };
+}
+`
+
+type fakeBrowser struct {
+	html string
+}
+
+func (b fakeBrowser) Load(_ context.Context, url string, _ ...browser.LoadOption) (*browser.Result, error) {
+	return &browser.Result{FinalURL: url, HTML: b.html}, nil
+}
+
+func newScript(t *testing.T, source string, cfg Config) *Extractor {
+	t.Helper()
+	cfg.Name = "clips"
+	cfg.Hosts = []string{"clips.test"}
+	cfg.Source = source
+	e, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return e
+}
+
+func TestExtract(t *testing.T) {
+	var gotAccept string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/clips/abc" {
+			http.NotFound(w, r)
+			return
+		}
+		gotAccept = r.Header.Get("Accept")
+		_, _ = w.Write([]byte(`{"title": "A clip", "cover": "http://cdn.test/cover.jpg",
+			"files": [{"src": "https://cdn.test/720.mp4", "height": 720}, {"src": "https://cdn.test/360.mp4", "height": 360}]}`))
+	}))
+	defer srv.Close()
+
+	e := newScript(t, "const api = "+`"`+srv.URL+`";`+clipScript, Config{})
+
+	link := "https://clips.test/v/abc"
+	media, err := e.Extract(t.Context(), link)
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if gotAccept != "application/json" {
+		t.Fatalf("headers not sent: Accept = %q", gotAccept)
+	}
+	if media.Source != "clips" || media.RequestUrl != link || media.Title != "A clip" || media.Url != "https://cdn.test/cover.jpg" {
+		t.Fatalf("unexpected media %+v", media)
+	}
+	if len(media.Items) != 2 {
+		t.Fatalf("got %d items, want 2", len(media.Items))
+	}
+	if item := media.Items[0]; item.Type != models.MediaTypeVideo || item.Url != "https://cdn.test/720.mp4" ||
+		item.Height != 720 || item.Quality != "720p" {
+		t.Fatalf("unexpected item %+v", item)
+	}
+
+	t.Run("fail", func(t *testing.T) {
+		_, err := e.Extract(t.Context(), "https://clips.test/v/missing")
+		if !errors.Is(err, extractor.ErrNotFound) || !strings.Contains(err.Error(), "no clip missing") {
+			t.Fatalf("want ErrNotFound with the message, got %v", err)
+		}
+	})
+}
+
+func TestExtract_Browser(t *testing.T) {
+	e := newScript(t, `
+function extract(url) {
+  const page = browser.load(url);
+  return {items: regex.matchAll('<img src="([^"]+)"', page.html).map(m => ({type: "photo", url: m[1]}))};
+}`, Config{})
+	e.browser = fakeBrowser{html: `<img src="https://cdn.test/1.jpg"><img src="https://cdn.test/2.jpg">`}
+
+	media, err := e.Extract(t.Context(), "https://clips.test/p/1")
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if len(media.Items) != 2 || media.Items[1].Url != "https://cdn.test/2.jpg" || media.Items[0].Type != models.MediaTypePhoto {
+		t.Fatalf("unexpected media %+v", media)
+	}
+}
+
+func TestExtract_Errors(t *testing.T) {
+	tests := map[string]string{
+		"no extract function": `const x = 1;`,
+		"thrown":              `function extract(url) { throw new Error("boom"); }`,
+		"invalid result":      `function extract(url) { return {items: [{type: "gif", url: url}]}; }`,
+		"no items":            `function extract(url) { return {}; }`,
+		"bad regex":           `function extract(url) { return regex.match("(", url); }`,
+	}
+	for name, source := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := newScript(t, source, Config{}).Extract(t.Context(), "https://clips.test/v/1")
+			if err == nil || extractor.Kind(err) != nil {
+				t.Fatalf("want an unexpected failure, got %v", err)
+			}
+		})
+	}
+
+	if _, err := New(Config{Name: "broken", Hosts: []string{"clips.test"}, Source: "function extract("}); err == nil {
+		t.Fatal("want a compile error")
+	}
+}
+
+func TestExtract_Limits(t *testing.T) {
+	t.Run("timeout", func(t *testing.T) {
+		e := newScript(t, `function extract(url) { for (;;) {} }`, Config{Timeout: 100 * time.Millisecond})
+		start := time.Now()
+		if _, err := e.Extract(t.Context(), "https://clips.test/v/1"); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("want DeadlineExceeded, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 10*time.Second {
+			t.Fatalf("the script was not stopped, Extract took %s", elapsed)
+		}
+	})
+
+	t.Run("caller cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		time.AfterFunc(100*time.Millisecond, cancel)
+		e := newScript(t, `function extract(url) { for (;;) {} }`, Config{})
+		if _, err := e.Extract(ctx, "https://clips.test/v/1"); !errors.Is(err, context.Canceled) {
+			t.Fatalf("want Canceled, got %v", err)
+		}
+	})
+
+	t.Run("host results", func(t *testing.T) {
+		e := newScript(t, `
+function extract(url) {
+  let s = url;
+  for (;;) { s = regex.replace(".", s, "$0$0"); }
+}`, Config{MaxHostBytes: 1 << 20})
+		if _, err := e.Extract(t.Context(), "https://clips.test/v/1"); !errors.Is(err, ErrHostBytesLimit) {
+			t.Fatalf("want ErrHostBytesLimit, got %v", err)
+		}
+	})
+
+	t.Run("response body", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(strings.Repeat("x", 4096)))
+		}))
+		defer srv.Close()
+
+		e := newScript(t, `function extract(url) {
+  try { fetch(url); } catch (e) {}
+  return {items: [{type: "photo", url: url}]};
+}`, Config{MaxHostBytes: 1024})
+		if _, err := e.Extract(t.Context(), srv.URL); !errors.Is(err, ErrHostBytesLimit) {
+			t.Fatalf("want ErrHostBytesLimit, got %v", err)
+		}
+	})
+}
+
+func TestRegister(t *testing.T) {
+	r := extractor.NewRegistry()
+	if err := Register(r, Config{Name: "clips", Hosts: []string{"clips.test"}, Source: clipScript}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if chain := r.GetChainByHost("clips.test"); len(chain) != 1 || chain[0].Name() != "clips" {
+		t.Fatalf("unexpected chain %v", chain)
+	}
+}
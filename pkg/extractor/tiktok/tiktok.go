@@ -2,12 +2,19 @@ package tiktok
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
 
 	"github.com/sxwebdev/downloaderbot/internal/models"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
 	"github.com/sxwebdev/downloaderbot/pkg/tiktok"
 )
 
+func init() {
+	extractor.MustRegister(New())
+}
+
 // Extractor implements the extractor.Extractor interface for TikTok using a
 // real headless browser (pkg/tiktok), which bypasses TikTok's anti-bot where
 // the lux-based extractor fails.
@@ -24,15 +31,55 @@ func (e *Extractor) Name() string {
 }
 
 // Hosts returns the supported hosts, including the short-link domains
-// (vt./vm.) which are not normalized away by the registry.
+// (vt./vm.) which are not normalized away by the registry and the regional
+// subdomains (us., t., ...).
 func (e *Extractor) Hosts() []string {
-	return []string{"tiktok.com", "vt.tiktok.com", "vm.tiktok.com"}
+	return []string{"tiktok.com", "*.tiktok.com", "vt.tiktok.com", "vm.tiktok.com"}
+}
+
+// Patterns limits the extractor to video pages and short links, so profile
+// and search links are rejected before the browser is started.
+func (e *Extractor) Patterns() []extractor.Pattern {
+	return []extractor.Pattern{{Match: tiktok.IsVideoLink}}
+}
+
+// Canonicalize implements extractor.Canonicalizer: a video is identified by its
+// numeric ID whatever page or username the link carries.
+func (e *Extractor) Canonicalize(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	canonical, ok := tiktok.CanonicalURL(u)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", extractor.ErrUnsupportedURL, rawURL)
+	}
+	return canonical, nil
+}
+
+// Capabilities declares that TikTok videos are only downloadable with the
+// visit cookies and referer, which rules out inline mode: Telegram fetches
+// inline results from the bare URL.
+func (e *Extractor) Capabilities() extractor.Capabilities {
+	return extractor.Capabilities{
+		DownloadHeaders: true,
+		MediaTypes:      []models.MediaType{models.MediaTypeVideo},
+		Output:          extractor.OutputItems,
+	}
 }
 
 // Extract extracts media from a TikTok URL.
 func (e *Extractor) Extract(ctx context.Context, url string) (*models.Media, error) {
 	media, err := tiktok.GetVideo(ctx, url)
-	if err != nil {
+	switch {
+	case errors.Is(err, tiktok.ErrNoVideo):
+		// Let the next extractor in the chain try the link.
+		return nil, fmt.Errorf("failed to get tiktok video: %w: %w", extractor.ErrBlocked, err)
+	case errors.Is(err, tiktok.ErrNotFound):
+		return nil, fmt.Errorf("failed to get tiktok video: %w: %w", extractor.ErrNotFound, err)
+	case errors.Is(err, tiktok.ErrPrivate):
+		return nil, fmt.Errorf("failed to get tiktok video: %w: %w", extractor.ErrPrivate, err)
+	case err != nil:
 		return nil, fmt.Errorf("failed to get tiktok video: %w", err)
 	}
 
@@ -2,12 +2,22 @@ package youtube
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
 
+	ytclient "github.com/kkdai/youtube/v2"
 	"github.com/sxwebdev/downloaderbot/internal/models"
+	"github.com/sxwebdev/downloaderbot/pkg/extractor"
 	"github.com/sxwebdev/downloaderbot/pkg/youtube"
 )
 
+func init() {
+	extractor.MustRegister(New())
+}
+
 // Extractor implements the extractor.Extractor interface for YouTube
 type Extractor struct{}
 
@@ -26,21 +36,115 @@ func (e *Extractor) Name() string {
 func (e *Extractor) Hosts() []string {
 	return []string{
 		"youtube.com",
+		"*.youtube.com", // music.youtube.com and the like
 		"youtu.be",
 	}
 }
 
+var (
+	reVideoPath = regexp.MustCompile(`^/(?:shorts|embed|v|live)/([A-Za-z0-9_-]{11})`)
+	reShortLink = regexp.MustCompile(`^/([A-Za-z0-9_-]{11})$`)
+)
+
+// Patterns limits the extractor to links of a single video; channels,
+// playlists and search pages are rejected.
+func (e *Extractor) Patterns() []extractor.Pattern {
+	return []extractor.Pattern{
+		{Host: "youtube.com", Match: isWatchLink},
+		{Host: "youtube.com", Path: reVideoPath},
+		{Host: "*.youtube.com", Match: isWatchLink},
+		{Host: "*.youtube.com", Path: reVideoPath},
+		{Host: "youtu.be", Path: reShortLink},
+	}
+}
+
+// isWatchLink matches /watch?v=<id>.
+func isWatchLink(u *url.URL) bool {
+	return u.Path == "/watch" && len(u.Query().Get("v")) == 11
+}
+
+// videoID returns the ID of the video a link of Patterns points at. Other links
+// are left to youtube.ExtractShortcodeFromLink, which guesses the ID from any
+// 11-character part of the link, the host included.
+func videoID(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	if isWatchLink(u) {
+		return u.Query().Get("v"), nil
+	}
+	if m := reVideoPath.FindStringSubmatch(u.Path); m != nil {
+		return m[1], nil
+	}
+	if m := reShortLink.FindStringSubmatch(u.Path); m != nil && strings.EqualFold(u.Hostname(), "youtu.be") {
+		return m[1], nil
+	}
+	return youtube.ExtractShortcodeFromLink(rawURL)
+}
+
+// Canonicalize implements extractor.Canonicalizer: watch pages, shorts, embeds
+// and youtu.be links of a video all become https://www.youtube.com/watch?v=<id>,
+// without the share (si) and start time (t) parameters.
+func (e *Extractor) Canonicalize(rawURL string) (string, error) {
+	id, err := videoID(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", extractor.ErrUnsupportedURL, err)
+	}
+	return "https://www.youtube.com/watch?v=" + id, nil
+}
+
+// Capabilities declares YouTube as a format list: the variants of the video,
+// its video and audio qualities, are offered as download links. Inline
+// mode is not supported because of the file sizes.
+func (e *Extractor) Capabilities() extractor.Capabilities {
+	return extractor.Capabilities{
+		MediaTypes: []models.MediaType{models.MediaTypeVideo, models.MediaTypeAudio},
+		Output:     extractor.OutputFormats,
+	}
+}
+
+// errorKind maps the YouTube client errors to the extractor error taxonomy.
+func errorKind(err error) error {
+	var playability *ytclient.ErrPlayabiltyStatus
+	var status ytclient.ErrUnexpectedStatusCode
+
+	switch {
+	case errors.Is(err, ytclient.ErrVideoPrivate):
+		return extractor.ErrPrivate
+	case errors.Is(err, ytclient.ErrLoginRequired):
+		return extractor.ErrLoginRequired
+	case errors.Is(err, ytclient.ErrCipherNotFound), errors.Is(err, ytclient.ErrSignatureTimestampNotFound):
+		return extractor.ErrUpstreamChanged
+	case errors.As(err, &status):
+		return extractor.StatusError(int(status))
+	case errors.As(err, &playability):
+		switch {
+		case playability.Status == "ERROR":
+			return extractor.ErrNotFound
+		case playability.Status == "AGE_CHECK_REQUIRED":
+			return extractor.ErrLoginRequired
+		case strings.Contains(playability.Reason, "country"):
+			return extractor.ErrGeoBlocked
+		}
+	}
+	return nil
+}
+
 // Extract extracts media from YouTube URL
 func (e *Extractor) Extract(ctx context.Context, url string) (*models.Media, error) {
 	// Extract video ID from URL
-	videoID, err := youtube.ExtractShortcodeFromLink(url)
+	id, err := videoID(url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract video ID: %w", err)
+		return nil, fmt.Errorf("failed to extract video ID: %w: %w", extractor.ErrUnsupportedURL, err)
 	}
 
 	// Get video data
-	media, err := youtube.GetVideoByID(ctx, videoID)
+	media, err := youtube.GetVideoByID(ctx, id)
 	if err != nil {
+		if kind := errorKind(err); kind != nil {
+			return nil, fmt.Errorf("failed to get video: %w: %w", kind, err)
+		}
 		return nil, fmt.Errorf("failed to get video: %w", err)
 	}
 
@@ -0,0 +1,26 @@
+package youtube
+
+import "testing"
+
+func TestCanonicalize(t *testing.T) {
+	const want = "https://www.youtube.com/watch?v=dQw4w9WgXcQ"
+	links := []string{
+		"https://youtu.be/dQw4w9WgXcQ?si=AbCdEf",
+		"https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=30",
+		"https://m.youtube.com/watch?feature=share&v=dQw4w9WgXcQ",
+		"https://www.youtube.com/shorts/dQw4w9WgXcQ",
+		"https://www.youtube.com/live/dQw4w9WgXcQ?feature=share",
+		"https://music.youtube.com/watch?v=dQw4w9WgXcQ&list=RD1",
+	}
+
+	e := New()
+	for _, link := range links {
+		got, err := e.Canonicalize(link)
+		if err != nil {
+			t.Fatalf("Canonicalize(%s): %v", link, err)
+		}
+		if got != want {
+			t.Errorf("Canonicalize(%s) = %s, want %s", link, got, want)
+		}
+	}
+}
@@ -1,7 +1,10 @@
 package instagram
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"regexp"
@@ -77,7 +80,12 @@ func (f *BrowserFetcher) GetPost(ctx context.Context, code string) (*models.Medi
 	if err != nil {
 		return nil, err
 	}
-	return parseMediaFromHTML(res.HTML, code)
+
+	media, err := parseMediaFromHTML(res.HTML, code)
+	if errors.Is(err, ErrNoMedia) {
+		return nil, fmt.Errorf("shortcode %q: %w", code, pageError(res))
+	}
+	return media, err
 }
 
 // hasMediaJSON reports whether the rendered page already carries the embedded
@@ -101,12 +109,18 @@ func parseMediaFromHTML(html, code string) (*models.Media, error) {
 	}
 
 	if len(media.Items) == 0 {
-		return nil, fmt.Errorf("no media found on page for shortcode %q", code)
+		return nil, fmt.Errorf("shortcode %q: %w", code, ErrNoMedia)
 	}
 
 	media.Type = string(media.Items[0].Type)
 
 	media.Caption = captionForMedia(html, code)
+	if post, ok := postObject(html, code); ok {
+		setPostDetails(media, post)
+		if audio := audioFromPost(post, code); audio != nil {
+			media.Items = append(media.Items, audio)
+		}
+	}
 
 	media.Url = media.Items[0].Url
 	return media, nil
@@ -123,6 +137,23 @@ func parseMediaFromHTML(html, code string) (*models.Media, error) {
 // the occurrence closest before its media — and take the first caption after
 // it, which is the post's own.
 func captionForMedia(html, code string) string {
+	start := postIndex(html, code)
+
+	region := html
+	if start >= 0 {
+		region = html[start:]
+	}
+
+	if c := reCaption.FindStringSubmatch(region); len(c) > 1 {
+		return util.JSONUnescape(c[1])
+	}
+	return ""
+}
+
+// postIndex returns the byte offset of the `"code":"<shortcode>"` key of the
+// requested post's own object: the occurrence closest before its media, or
+// the first one when the media comes first. -1 when the page has none.
+func postIndex(html, code string) int {
 	anchor := `"code":"` + code + `"`
 
 	start := -1
@@ -132,16 +163,169 @@ func captionForMedia(html, code string) string {
 	if start < 0 {
 		start = strings.Index(html, anchor)
 	}
+	return start
+}
 
-	region := html
-	if start >= 0 {
-		region = html[start:]
+// pagePost holds the details of a post object in the mobile "items" format.
+type pagePost struct {
+	TakenAt      int64    `json:"taken_at"`
+	LikeCount    uint64   `json:"like_count"`
+	CommentCount uint64   `json:"comment_count"`
+	User         pageUser `json:"user"`
+	Owner        pageUser `json:"owner"`
+}
+
+type pageUser struct {
+	Username      string `json:"username"`
+	IsVerified    bool   `json:"is_verified"`
+	IsPrivate     bool   `json:"is_private"`
+	ProfilePicURL string `json:"profile_pic_url"`
+}
+
+// postObject returns the requested post's own object, false when the page
+// doesn't carry it in one piece. Reading the object as a whole, rather than
+// matching its keys in the page, keeps the details of the decoy posts and of
+// the caption's own "user" out.
+func postObject(html, code string) (string, bool) {
+	start := postIndex(html, code)
+	if start < 0 {
+		return "", false
+	}
+	return balancedJSON(html, enclosingObject(html, start))
+}
+
+// setPostDetails fills the author, publication time and engagement counts of
+// media from the post object, leaving them unset when it doesn't decode.
+func setPostDetails(media *models.Media, obj string) {
+	var post pagePost
+	if err := json.Unmarshal([]byte(obj), &post); err != nil {
+		return
 	}
 
-	if c := reCaption.FindStringSubmatch(region); len(c) > 1 {
-		return util.JSONUnescape(c[1])
+	user := post.User
+	if user.Username == "" {
+		user = post.Owner
 	}
-	return ""
+	if user.Username != "" {
+		media.Author = user.Username
+		media.Owner = &models.Owner{
+			Username:          user.Username,
+			IsVerified:        user.IsVerified,
+			IsPrivate:         user.IsPrivate,
+			ProfilePictureURL: user.ProfilePicURL,
+		}
+	}
+	media.TakenAt = post.TakenAt
+	media.Likes = post.LikeCount
+	media.Comments = post.CommentCount
+}
+
+// pageClipsAudio holds the soundtrack of a reel in the mobile "items" format:
+// a licensed song (music_info) or the audio recorded with the reel
+// (original_sound_info).
+type pageClipsAudio struct {
+	ClipsMetadata struct {
+		MusicInfo struct {
+			MusicAssetInfo struct {
+				Title                  string `json:"title"`
+				DisplayArtist          string `json:"display_artist"`
+				ProgressiveDownloadURL string `json:"progressive_download_url"`
+				DurationInMs           int    `json:"duration_in_ms"`
+			} `json:"music_asset_info"`
+		} `json:"music_info"`
+		OriginalSoundInfo struct {
+			OriginalAudioTitle     string `json:"original_audio_title"`
+			ProgressiveDownloadURL string `json:"progressive_download_url"`
+			DurationInMs           int    `json:"duration_in_ms"`
+			IgArtist               struct {
+				Username string `json:"username"`
+			} `json:"ig_artist"`
+		} `json:"original_sound_info"`
+	} `json:"clips_metadata"`
+	ClipsMusicAttributionInfo struct {
+		ArtistName string `json:"artist_name"`
+		SongName   string `json:"song_name"`
+	} `json:"clips_music_attribution_info"`
+}
+
+// audioFromPost returns the soundtrack of a reel as an audio item, nil when
+// the post object carries no audio URL. The attribution shown under the reel
+// names the track when its asset doesn't.
+func audioFromPost(obj, code string) *models.MediaItem {
+	var post pageClipsAudio
+	if err := json.Unmarshal([]byte(obj), &post); err != nil {
+		return nil
+	}
+
+	music := post.ClipsMetadata.MusicInfo.MusicAssetInfo
+	original := post.ClipsMetadata.OriginalSoundInfo
+	item := &models.MediaItem{
+		Shortcode: code,
+		Type:      models.MediaTypeAudio,
+		MimeType:  "audio/mp4",
+	}
+	var durationMs int
+	switch {
+	case music.ProgressiveDownloadURL != "":
+		item.Url, item.Title, item.Artist = music.ProgressiveDownloadURL, music.Title, music.DisplayArtist
+		durationMs = music.DurationInMs
+	case original.ProgressiveDownloadURL != "":
+		item.Url, item.Title, item.Artist = original.ProgressiveDownloadURL, original.OriginalAudioTitle, original.IgArtist.Username
+		durationMs = original.DurationInMs
+	default:
+		return nil
+	}
+	item.Duration = int(math.Round(float64(durationMs) / 1000))
+
+	attribution := post.ClipsMusicAttributionInfo
+	item.Title = cmp.Or(item.Title, attribution.SongName)
+	item.Artist = cmp.Or(item.Artist, attribution.ArtistName)
+	return item
+}
+
+// enclosingObject returns the offset of the '{' opening the innermost JSON
+// object around pos, -1 when there is none. The scan starts at the <script>
+// holding pos, since the markup before it is not JSON.
+func enclosingObject(s string, pos int) int {
+	from := 0
+	if i := strings.LastIndex(s[:pos], "<script"); i >= 0 {
+		if j := strings.IndexByte(s[i:pos], '>'); j >= 0 {
+			from = i + j + 1
+		}
+	}
+
+	var opens []int
+	inStr, esc := false, false
+	for i := from; i < pos; i++ {
+		c := s[i]
+		if inStr {
+			switch {
+			case esc:
+				esc = false
+			case c == '\\':
+				esc = true
+			case c == '"':
+				inStr = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inStr = true
+		case '{', '[':
+			opens = append(opens, i)
+		case '}', ']':
+			if len(opens) > 0 {
+				opens = opens[:len(opens)-1]
+			}
+		}
+	}
+	for i := len(opens) - 1; i >= 0; i-- {
+		if s[opens[i]] == '{' {
+			return opens[i]
+		}
+	}
+	return -1
 }
 
 // mediaBlockIndex returns the byte offset of the first embedded media (video or
@@ -182,7 +366,9 @@ func parseCarousel(html, code string) []*models.MediaItem {
 }
 
 // itemFromBlock builds a single media item from a JSON block, preferring the
-// video URL when the block describes a video.
+// video URL when the block describes a video. The first of the video versions
+// is the default format; all of them, and the DASH representations, are
+// variants to choose from.
 func itemFromBlock(block, code string) *models.MediaItem {
 	if loc := reVideoVersion.FindStringSubmatchIndex(block); len(loc) > 0 {
 		item := &models.MediaItem{
@@ -193,6 +379,7 @@ func itemFromBlock(block, code string) *models.MediaItem {
 		item.Width, item.Height = dimensionsForItem(block, loc[0])
 		item.Duration = durationForItem(block, loc[0])
 		item.ThumbnailUrl = thumbnailForItem(block, loc[0])
+		item.Variants = renditionsForItem(block, loc[0])
 		return item
 	}
 
@@ -0,0 +1,206 @@
+package instagram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sxwebdev/downloaderbot/internal/models"
+)
+
+// Names of the fetch strategies, as accepted by NewStrategy.
+const (
+	StrategyGraphQL = "graphql"
+	StrategyEmbed   = "embed"
+	StrategyBrowser = "browser"
+)
+
+// DefaultCooldown is how long a ChainFetcher skips a broken strategy.
+const DefaultCooldown = 5 * time.Minute
+
+// Strategy is a named Fetcher tried by a ChainFetcher.
+type Strategy struct {
+	Name    string
+	Fetcher Fetcher
+	// Timeout limits a single GetPost of the strategy; 0 leaves the context
+	// of the request alone.
+	Timeout time.Duration
+}
+
+// NewStrategy returns the strategy called name.
+func NewStrategy(name string, timeout time.Duration) (Strategy, error) {
+	var f Fetcher
+	switch name {
+	case StrategyGraphQL:
+		f = NewGraphQLFetcher()
+	case StrategyEmbed:
+		f = NewEmbedFetcher()
+	case StrategyBrowser:
+		f = NewBrowserFetcher()
+	default:
+		return Strategy{}, fmt.Errorf("unknown instagram fetch strategy %q", name)
+	}
+	return Strategy{Name: name, Fetcher: f, Timeout: timeout}, nil
+}
+
+// DefaultStrategies tries the cheap HTTP strategies before starting a
+// browser.
+func DefaultStrategies() []Strategy {
+	return []Strategy{
+		{Name: StrategyGraphQL, Fetcher: NewGraphQLFetcher(), Timeout: 10 * time.Second},
+		{Name: StrategyEmbed, Fetcher: NewEmbedFetcher(), Timeout: 10 * time.Second},
+		{Name: StrategyBrowser, Fetcher: NewBrowserFetcher()},
+	}
+}
+
+// ChainObserver is told what a ChainFetcher does, for metrics.
+type ChainObserver interface {
+	// ObserveAttempt is called after each GetPost of a strategy.
+	ObserveAttempt(strategy string, started time.Time, err error)
+	// ObserveServed is called once per request with the strategy that served
+	// it, "" when none did.
+	ObserveServed(strategy string)
+	// SetHealthy is called when a strategy is found broken or working again.
+	SetHealthy(strategy string, healthy bool)
+}
+
+// ChainOption configures a ChainFetcher.
+type ChainOption func(*ChainFetcher)
+
+// WithCooldown sets how long a broken strategy is skipped.
+func WithCooldown(d time.Duration) ChainOption {
+	return func(c *ChainFetcher) { c.cooldown = d }
+}
+
+// WithObserver sets the observer of the chain.
+func WithObserver(o ChainObserver) ChainOption {
+	return func(c *ChainFetcher) { c.observer = o }
+}
+
+// ChainFetcher tries its strategies in order until one serves the post.
+//
+// A strategy that failed on a post another strategy then served is broken:
+// Instagram challenges it, or changed what it parses. It is skipped for the
+// cooldown, and only tried when the strategies still in use all fail. A post
+// that no strategy serves says nothing about them, as it is most likely gone.
+type ChainFetcher struct {
+	strategies []Strategy
+	cooldown   time.Duration
+	observer   ChainObserver
+	now        func() time.Time
+
+	mu sync.Mutex
+	// brokenUntil holds the end of the cooldown of the broken strategies.
+	brokenUntil map[string]time.Time
+}
+
+// NewChainFetcher creates a fetcher trying strategies in order.
+func NewChainFetcher(strategies []Strategy, opts ...ChainOption) *ChainFetcher {
+	c := &ChainFetcher{
+		strategies:  strategies,
+		cooldown:    DefaultCooldown,
+		observer:    nopObserver{},
+		now:         time.Now,
+		brokenUntil: make(map[string]time.Time, len(strategies)),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	for _, s := range strategies {
+		c.observer.SetHealthy(s.Name, true)
+	}
+	return c
+}
+
+// GetPost implements Fetcher. Errors telling the post is missing or private
+// end the chain, since another strategy would only tell the same.
+func (c *ChainFetcher) GetPost(ctx context.Context, code string) (*models.Media, error) {
+	var (
+		errs   []error
+		failed []string
+	)
+	for _, s := range c.order() {
+		media, err := c.try(ctx, s, code)
+		if err == nil {
+			c.observer.ObserveServed(s.Name)
+			c.markHealthy(s.Name)
+			c.markBroken(failed)
+			return media, nil
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", s.Name, err))
+		if ctx.Err() != nil || errors.Is(err, ErrNotFound) || errors.Is(err, ErrPrivate) {
+			break
+		}
+		failed = append(failed, s.Name)
+	}
+
+	c.observer.ObserveServed("")
+	if len(errs) == 0 {
+		return nil, errors.New("no instagram fetch strategy configured")
+	}
+	return nil, errors.Join(errs...)
+}
+
+func (c *ChainFetcher) try(ctx context.Context, s Strategy, code string) (*models.Media, error) {
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+
+	started := time.Now()
+	media, err := s.Fetcher.GetPost(ctx, code)
+	if err == nil && (media == nil || len(media.Items) == 0) {
+		err = ErrNoMedia
+	}
+	c.observer.ObserveAttempt(s.Name, started, err)
+	return media, err
+}
+
+// order returns the strategies in use, then the broken ones.
+func (c *ChainFetcher) order() []Strategy {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	inUse := make([]Strategy, 0, len(c.strategies))
+	var broken []Strategy
+	for _, s := range c.strategies {
+		if until, ok := c.brokenUntil[s.Name]; ok && now.Before(until) {
+			broken = append(broken, s)
+			continue
+		}
+		inUse = append(inUse, s)
+	}
+	return append(inUse, broken...)
+}
+
+func (c *ChainFetcher) markBroken(names []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	until := c.now().Add(c.cooldown)
+	for _, name := range names {
+		c.brokenUntil[name] = until
+		c.observer.SetHealthy(name, false)
+	}
+}
+
+func (c *ChainFetcher) markHealthy(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.brokenUntil[name]; ok {
+		delete(c.brokenUntil, name)
+		c.observer.SetHealthy(name, true)
+	}
+}
+
+type nopObserver struct{}
+
+func (nopObserver) ObserveAttempt(string, time.Time, error) {}
+func (nopObserver) ObserveServed(string)                    {}
+func (nopObserver) SetHealthy(string, bool)                 {}
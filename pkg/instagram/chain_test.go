@@ -0,0 +1,178 @@
+package instagram
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sxwebdev/downloaderbot/internal/models"
+)
+
+// fakeFetcher fails with err, or serves a post when err is nil.
+type fakeFetcher struct {
+	err   error
+	calls int
+}
+
+func (f *fakeFetcher) GetPost(ctx context.Context, code string) (*models.Media, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &models.Media{Shortcode: code, Items: []*models.MediaItem{{Url: "https://cdn/" + code}}}, nil
+}
+
+// slowFetcher waits for the end of the context.
+type slowFetcher struct{}
+
+func (slowFetcher) GetPost(ctx context.Context, code string) (*models.Media, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+type recordingObserver struct {
+	served  []string
+	healthy map[string]bool
+}
+
+func (o *recordingObserver) ObserveAttempt(string, time.Time, error) {}
+func (o *recordingObserver) ObserveServed(strategy string)           { o.served = append(o.served, strategy) }
+func (o *recordingObserver) SetHealthy(strategy string, healthy bool) {
+	o.healthy[strategy] = healthy
+}
+
+func TestChainFetcher_SkipsBrokenStrategy(t *testing.T) {
+	graphql, embed := &fakeFetcher{err: errSessionInvalid}, &fakeFetcher{}
+	obs := &recordingObserver{healthy: map[string]bool{}}
+	now := time.Unix(1_700_000_000, 0)
+	c := NewChainFetcher([]Strategy{
+		{Name: StrategyGraphQL, Fetcher: graphql},
+		{Name: StrategyEmbed, Fetcher: embed},
+	}, WithCooldown(time.Minute), WithObserver(obs))
+	c.now = func() time.Time { return now }
+
+	for range 2 {
+		if _, err := c.GetPost(t.Context(), "code"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if graphql.calls != 1 || embed.calls != 2 {
+		t.Fatalf("calls = %d/%d, want the broken strategy tried once", graphql.calls, embed.calls)
+	}
+	if obs.healthy[StrategyGraphQL] || !obs.healthy[StrategyEmbed] {
+		t.Fatalf("health = %v", obs.healthy)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := c.GetPost(t.Context(), "code"); err != nil || graphql.calls != 2 {
+		t.Fatalf("strategy not tried after the cooldown: err %v, calls %d", err, graphql.calls)
+	}
+
+	// The skipped strategy is tried again when the others fail, and is in
+	// use again once it serves a post.
+	graphql.err, embed.err = nil, errors.New("challenge")
+	if _, err := c.GetPost(t.Context(), "code"); err != nil {
+		t.Fatal(err)
+	}
+	if !obs.healthy[StrategyGraphQL] || obs.healthy[StrategyEmbed] {
+		t.Fatalf("health = %v", obs.healthy)
+	}
+	want := []string{StrategyEmbed, StrategyEmbed, StrategyEmbed, StrategyGraphQL}
+	if !slices.Equal(obs.served, want) {
+		t.Fatalf("served = %v, want %v", obs.served, want)
+	}
+}
+
+func TestChainFetcher_Errors(t *testing.T) {
+	t.Run("all strategies fail", func(t *testing.T) {
+		obs := &recordingObserver{healthy: map[string]bool{}}
+		c := NewChainFetcher([]Strategy{
+			{Name: StrategyGraphQL, Fetcher: &fakeFetcher{err: errSessionInvalid}},
+			{Name: StrategyBrowser, Fetcher: &fakeFetcher{err: ErrNoMedia}},
+		}, WithObserver(obs))
+
+		_, err := c.GetPost(t.Context(), "code")
+		if !errors.Is(err, errSessionInvalid) || !errors.Is(err, ErrNoMedia) {
+			t.Fatalf("want the errors of both strategies, got %v", err)
+		}
+		// Nothing served the post, so nothing tells the strategies broken.
+		if !obs.healthy[StrategyGraphQL] || !obs.healthy[StrategyBrowser] {
+			t.Fatalf("health = %v", obs.healthy)
+		}
+		if !slices.Equal(obs.served, []string{""}) {
+			t.Fatalf("served = %v", obs.served)
+		}
+	})
+
+	t.Run("missing post ends the chain", func(t *testing.T) {
+		next := &fakeFetcher{}
+		c := NewChainFetcher([]Strategy{
+			{Name: StrategyBrowser, Fetcher: &fakeFetcher{err: ErrNotFound}},
+			{Name: StrategyEmbed, Fetcher: next},
+		})
+		if _, err := c.GetPost(t.Context(), "code"); !errors.Is(err, ErrNotFound) || next.calls != 0 {
+			t.Fatalf("err %v, next strategy called %d time(s)", err, next.calls)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		c := NewChainFetcher([]Strategy{
+			{Name: StrategyGraphQL, Fetcher: slowFetcher{}, Timeout: time.Millisecond},
+			{Name: StrategyEmbed, Fetcher: &fakeFetcher{}},
+		})
+		if _, err := c.GetPost(t.Context(), "code"); err != nil {
+			t.Fatalf("want the next strategy to serve after a timeout, got %v", err)
+		}
+	})
+}
+
+// embedVideoPage is the embed page of a reel without the post data script:
+// all it shows is the poster of the video.
+const embedVideoPage = `<html><body>
+<div class="Embed Captioned" data-media-type="GraphVideo">
+<img class="EmbeddedMediaImage" src="https://cdn.test/poster.jpg">
+</div>
+</body></html>`
+
+func TestChainFetcher_EmbedVideoPoster(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(embedVideoPage))
+	}))
+	defer srv.Close()
+
+	obs := &recordingObserver{healthy: map[string]bool{}}
+	browser := &fakeFetcher{}
+	c := NewChainFetcher([]Strategy{
+		{Name: StrategyEmbed, Fetcher: &EmbedFetcher{pageURL: srv.URL + "/p/%s/embed/captioned/"}},
+		{Name: StrategyBrowser, Fetcher: browser},
+	}, WithObserver(obs))
+
+	media, err := c.GetPost(t.Context(), "code")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if browser.calls != 1 || media.Items[0].Url != "https://cdn/code" {
+		t.Fatalf("want the reel from the next strategy, not the poster: %+v", media.Items[0])
+	}
+	if !slices.Equal(obs.served, []string{StrategyBrowser}) || obs.healthy[StrategyEmbed] {
+		t.Fatalf("served = %v, health = %v", obs.served, obs.healthy)
+	}
+}
+
+func TestFetchEmbedPage_Canceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := fetchEmbedPage(ctx, srv.URL); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("want the context error, got %v", err)
+	}
+}
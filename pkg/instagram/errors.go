@@ -0,0 +1,48 @@
+package instagram
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/sxwebdev/downloaderbot/pkg/browser"
+)
+
+// Reasons a post page carries no media. pkg/extractor/instagram maps them to
+// the extractor error taxonomy.
+var (
+	ErrNotFound      = errors.New("instagram post not found")
+	ErrPrivate       = errors.New("instagram post is private")
+	ErrLoginRequired = errors.New("instagram requires login to show the post")
+	// ErrNoMedia means the page loaded but none of the media patterns matched,
+	// which is what a change of the embedded JSON looks like.
+	ErrNoMedia = errors.New("no media found on instagram page")
+)
+
+// Markers of the pages Instagram serves instead of a post.
+var (
+	notFoundMarkers = []string{"Sorry, this page isn't available", "Page not found"}
+	privateMarkers  = []string{"This account is private", "This Account is Private"}
+)
+
+// pageError tells why a loaded post page carries no media.
+func pageError(res *browser.Result) error {
+	switch {
+	case strings.Contains(res.FinalURL, "/accounts/login"):
+		return ErrLoginRequired
+	case containsAny(res.HTML, notFoundMarkers):
+		return ErrNotFound
+	case containsAny(res.HTML, privateMarkers):
+		return ErrPrivate
+	default:
+		return ErrNoMedia
+	}
+}
+
+func containsAny(s string, markers []string) bool {
+	for _, m := range markers {
+		if strings.Contains(s, m) {
+			return true
+		}
+	}
+	return false
+}
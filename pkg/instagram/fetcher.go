@@ -2,6 +2,7 @@ package instagram
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/sxwebdev/downloaderbot/internal/models"
 )
@@ -26,3 +27,34 @@ func NewAPIFetcher() *APIFetcher { return &APIFetcher{} }
 func (f *APIFetcher) GetPost(ctx context.Context, code string) (*models.Media, error) {
 	return GetPostWithCode(ctx, code)
 }
+
+// GraphQLFetcher retrieves posts from Instagram's GraphQL endpoint with an
+// anonymous web session. It is the cheapest way to get a post, and the one
+// whose response carries the most details.
+type GraphQLFetcher struct{}
+
+// NewGraphQLFetcher creates the GraphQL fetcher.
+func NewGraphQLFetcher() *GraphQLFetcher { return &GraphQLFetcher{} }
+
+// GetPost implements Fetcher using the GraphQL endpoint.
+func (f *GraphQLFetcher) GetPost(ctx context.Context, code string) (*models.Media, error) {
+	return gqlRequest(ctx, code)
+}
+
+// EmbedFetcher retrieves posts from their embed page, which Instagram serves
+// to third-party sites and so challenges less than the GraphQL endpoint.
+type EmbedFetcher struct {
+	// pageURL is the embed page by shortcode, embedPageURL when empty.
+	pageURL string
+}
+
+// NewEmbedFetcher creates the embed page fetcher.
+func NewEmbedFetcher() *EmbedFetcher { return &EmbedFetcher{} }
+
+// GetPost implements Fetcher using the embed page.
+func (f *EmbedFetcher) GetPost(ctx context.Context, code string) (*models.Media, error) {
+	if f.pageURL != "" {
+		return fetchEmbedPage(ctx, fmt.Sprintf(f.pageURL, code))
+	}
+	return embedRequest(ctx, code)
+}
@@ -3,6 +3,7 @@
 package instagram
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
@@ -13,6 +14,7 @@ import (
 	"net/http/cookiejar"
 	"net/url"
 	"regexp"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -145,70 +147,88 @@ func GetPostWithCode(ctx context.Context, code string) (*models.Media, error) {
 	return nil, errors.New("failed to fetch the post\nthe page might be \"private\", or\nthe link is completely wrong")
 }
 
+// embedPageURL is the embed page of a post, by shortcode.
+const embedPageURL = igBaseURL + "/p/%s/embed/captioned/"
+
 func embedRequest(ctx context.Context, code string) (*models.Media, error) {
-	URL := fmt.Sprintf("https://www.instagram.com/p/%s/embed/captioned/", code)
+	return fetchEmbedPage(ctx, fmt.Sprintf(embedPageURL, code))
+}
 
-	var embeddedMediaImage string
-	var caption string
+// fetchEmbedPage reads the post from its embed page: the post data the page
+// script carries, or else the single media the page shows. For a video that
+// is only its poster image, which is not the post.
+func fetchEmbedPage(ctx context.Context, pageURL string) (*models.Media, error) {
+	var (
+		embeddedMediaImage string
+		videoURL           string
+		isVideo            bool
+		caption            string
+		parseErr           error
+	)
 	embedResponse := response.EmbedResponse{}
 
-	errChan := make(chan error, 1)
-	go func() {
-		collector := colly.NewCollector()
-		collector.SetClient(util.DefaultHttpClient())
-
-		collector.OnHTML("img.EmbeddedMediaImage", func(e *colly.HTMLElement) {
-			embeddedMediaImage = e.Attr("src")
-		})
-
-		collector.OnHTML("div[class=Caption]", func(e *colly.HTMLElement) {
-			r := regexp.MustCompile(`.*</a><br/><br/>(.*)<div class="CaptionComments">.*`)
-			match := r.FindStringSubmatch(fmt.Sprint(e.DOM.Html()))
-			if len(match) > 0 {
-				caption = match[1]
-			}
-		})
+	collector := colly.NewCollector(colly.StdlibContext(ctx))
+	collector.SetClient(util.DefaultHttpClient())
 
-		collector.OnHTML("video", func(e *colly.HTMLElement) {
-			embeddedMediaImage = e.Attr("src")
-		})
+	collector.OnHTML("div.Embed", func(e *colly.HTMLElement) {
+		isVideo = e.Attr("data-media-type") == "GraphVideo"
+	})
 
-		collector.OnHTML("script", func(e *colly.HTMLElement) {
-			r := regexp.MustCompile(`\\\"gql_data\\\":([\s\S]*)\}\"\}\]\]\,\[\"NavigationMetrics`)
-			match := r.FindStringSubmatch(e.Text)
+	collector.OnHTML("img.EmbeddedMediaImage", func(e *colly.HTMLElement) {
+		embeddedMediaImage = e.Attr("src")
+	})
 
-			if len(match) < 2 {
-				return
-			}
+	collector.OnHTML("div[class=Caption]", func(e *colly.HTMLElement) {
+		r := regexp.MustCompile(`.*</a><br/><br/>(.*)<div class="CaptionComments">.*`)
+		match := r.FindStringSubmatch(fmt.Sprint(e.DOM.Html()))
+		if len(match) > 0 {
+			caption = match[1]
+		}
+	})
 
-			s := strings.ReplaceAll(match[1], `\"`, `"`)
-			s = strings.ReplaceAll(s, `\\/`, `/`)
-			s = strings.ReplaceAll(s, `\\`, `\`)
+	collector.OnHTML("video", func(e *colly.HTMLElement) {
+		isVideo = true
+		if src := e.Attr("src"); src != "" {
+			videoURL = src
+		}
+	})
 
-			errChan <- json.Unmarshal([]byte(s), &embedResponse)
-		})
+	collector.OnHTML("script", func(e *colly.HTMLElement) {
+		r := regexp.MustCompile(`\\\"gql_data\\\":([\s\S]*)\}\"\}\]\]\,\[\"NavigationMetrics`)
+		match := r.FindStringSubmatch(e.Text)
 
-		collector.OnRequest(func(r *colly.Request) {
-			r.Headers.Set("Accept", "*/*")
-			r.Headers.Set("Host", "www.instagram.com")
-			r.Headers.Set("Referer", "https://www.instagram.com/")
-			r.Headers.Set("DNT", "1")
-			r.Headers.Set("Sec-Fetch-Dest", "document")
-			r.Headers.Set("Sec-Fetch-Mode", "navigate")
-			r.Headers.Set("Sec-Fetch-Site", "same-origin")
-			r.Headers.Set("User-Agent", browser.Chrome())
-		})
+		if len(match) < 2 {
+			return
+		}
 
-		errChan <- collector.Visit(URL)
-	}()
+		s := strings.ReplaceAll(match[1], `\"`, `"`)
+		s = strings.ReplaceAll(s, `\\/`, `/`)
+		s = strings.ReplaceAll(s, `\\`, `\`)
 
-	select {
-	case err := <-errChan:
-		if err != nil {
-			return nil, err
+		if err := json.Unmarshal([]byte(s), &embedResponse); err != nil && parseErr == nil {
+			parseErr = err
+		}
+	})
+
+	collector.OnRequest(func(r *colly.Request) {
+		r.Headers.Set("Accept", "*/*")
+		r.Headers.Set("Host", "www.instagram.com")
+		r.Headers.Set("Referer", "https://www.instagram.com/")
+		r.Headers.Set("DNT", "1")
+		r.Headers.Set("Sec-Fetch-Dest", "document")
+		r.Headers.Set("Sec-Fetch-Mode", "navigate")
+		r.Headers.Set("Sec-Fetch-Site", "same-origin")
+		r.Headers.Set("User-Agent", browser.Chrome())
+	})
+
+	if err := collector.Visit(pageURL); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("failed request by context: %w", ctxErr)
 		}
-	case <-ctx.Done():
-		return nil, fmt.Errorf("failed request by context")
+		return nil, err
+	}
+	if parseErr != nil {
+		return nil, parseErr
 	}
 
 	if !embedResponse.IsEmpty() {
@@ -216,20 +236,21 @@ func embedRequest(ctx context.Context, code string) (*models.Media, error) {
 		return &resp, nil
 	}
 
-	if embeddedMediaImage != "" {
-		return &models.Media{
-			Caption: caption,
-			Url:     embeddedMediaImage,
-			Items: []*models.MediaItem{
-				{
-					Type: models.MediaTypePhoto,
-					Url:  embeddedMediaImage,
-				},
-			},
-		}, nil
+	item := &models.MediaItem{Type: models.MediaTypePhoto, Url: embeddedMediaImage}
+	switch {
+	case videoURL != "":
+		item = &models.MediaItem{Type: models.MediaTypeVideo, Url: videoURL}
+	case isVideo:
+		return nil, fmt.Errorf("embed page only shows the poster of the video: %w", ErrNoMedia)
+	case embeddedMediaImage == "":
+		return nil, fmt.Errorf("embed response empty: %w", ErrNoMedia)
 	}
 
-	return nil, errors.New("embed response empty")
+	return &models.Media{
+		Caption: caption,
+		Url:     item.Url,
+		Items:   []*models.MediaItem{item},
+	}, nil
 }
 
 func gqlRequest(ctx context.Context, code string) (*models.Media, error) {
@@ -297,6 +318,29 @@ func doGQLRequest(ctx context.Context, sess *igSession, code string) (*models.Me
 		return nil, err
 	}
 
+	return parseGraphQL(body, code)
+}
+
+// addDashVariants offers the representations of the DASH manifest of a video
+// item as its variants. The progressive video stays the default format, the
+// only one with both video and audio.
+func addDashVariants(item *models.MediaItem, manifest string) {
+	if item.Type != models.MediaTypeVideo || manifest == "" {
+		return
+	}
+	dash, err := parseDashManifest(manifest)
+	if err != nil || len(dash) == 0 {
+		return
+	}
+	progressive := item.AllVariants()[0]
+	progressive.Quality = qualityLabel(item.Width, item.Height)
+	progressive.MimeType = "video/mp4"
+	item.Variants = append([]*models.Variant{progressive}, dash...)
+}
+
+// parseGraphQL converts the GraphQL response for a post: a single video or
+// photo, or every child of a carousel (sidecar).
+func parseGraphQL(body []byte, code string) (*models.Media, error) {
 	gqlResp := new(response.GrpahSQLResponse)
 	if err := json.Unmarshal(body, gqlResp); err != nil {
 		// Instagram returns a non-JSON error envelope (prefixed with "for (;;);")
@@ -310,37 +354,99 @@ func doGQLRequest(ctx context.Context, sess *igSession, code string) (*models.Me
 		return nil, fmt.Errorf("%w: empty graphql response for shortcode %q", errSessionInvalid, code)
 	}
 
-	if !data.IsVideo {
-		return nil, fmt.Errorf("response is not video")
+	media := &models.Media{
+		Id:        data.ID,
+		Shortcode: data.Shortcode,
+		Title:     data.Title,
+		Author:    data.Owner.Username,
+		Likes:     uint64(max(data.EdgeMediaPreviewLike.Count, 0)),
+		Comments:  uint64(max(data.EdgeMediaToParentComment.Count, data.EdgeMediaPreviewComment.Count, 0)),
+		TakenAt:   int64(data.TakenAtTimestamp),
+	}
+	if owner := data.Owner; owner.Username != "" {
+		media.Owner = &models.Owner{
+			Id:                owner.ID,
+			ProfilePictureURL: owner.ProfilePicURL,
+			Username:          owner.Username,
+			Followers:         uint64(max(owner.EdgeFollowedBy.Count, 0)),
+			IsPrivate:         owner.IsPrivate,
+			IsVerified:        owner.IsVerified,
+		}
 	}
 
-	// Instagram reports a fractional duration; Telegram takes whole seconds.
-	thumbnail := data.ThumbnailSrc
-	if thumbnail == "" {
-		thumbnail = data.DisplayURL
+	if children := data.EdgeSidecarToChildren.Edges; len(children) > 0 {
+		for _, edge := range children {
+			child := edge.Node
+			item := graphQLItem(child.IsVideo, child.VideoURL, child.DisplayURL, child.DisplayResources)
+			if item == nil {
+				continue
+			}
+			item.Id = child.ID
+			item.Shortcode = child.Shortcode
+			item.Width, item.Height = child.Dimensions.Width, child.Dimensions.Height
+			item.Duration = int(math.Round(child.VideoDuration))
+			addDashVariants(item, child.DashInfo.VideoDashManifest)
+			media.Items = append(media.Items, item)
+		}
+	} else if item := graphQLItem(data.IsVideo, data.VideoURL, data.DisplayURL, data.DisplayResources); item != nil {
+		item.Id = data.ID
+		item.Shortcode = data.Shortcode
+		item.Width, item.Height = data.Dimensions.Width, data.Dimensions.Height
+		item.Duration = int(math.Round(data.VideoDuration))
+		if item.Type == models.MediaTypeVideo && data.ThumbnailSrc != "" {
+			item.ThumbnailUrl = data.ThumbnailSrc
+		}
+		addDashVariants(item, data.DashInfo.VideoDashManifest)
+		media.Items = append(media.Items, item)
 	}
 
-	resp := &models.Media{
-		Title: data.Title,
-		Items: []*models.MediaItem{
-			{
-				Shortcode:    data.Shortcode,
-				Type:         models.MediaTypeVideo,
-				Url:          data.VideoURL,
-				Width:        data.Dimensions.Width,
-				Height:       data.Dimensions.Height,
-				Duration:     int(math.Round(data.VideoDuration)),
-				ThumbnailUrl: thumbnail,
-			},
-		},
+	if len(media.Items) == 0 {
+		return nil, fmt.Errorf("shortcode %q: %w", code, ErrNoMedia)
 	}
 
+	media.Type = string(media.Items[0].Type)
+	media.Url = media.Items[0].Url
+
 	if len(data.EdgeMediaToCaption.Edges) > 0 {
 		edge := data.EdgeMediaToCaption.Edges[0]
-		resp.Caption = edge.Node.Text
+		media.Caption = edge.Node.Text
 	}
 
-	return resp, nil
+	return media, nil
+}
+
+// graphQLItem builds the item of a GraphQL node, or returns nil when the node
+// carries no media URL. Photos are the largest of the display resources and
+// get the smallest as their thumbnail; videos get their cover frame. The
+// caller fills in the dimensions and the duration, which Instagram reports in
+// fractional seconds and Telegram takes whole.
+func graphQLItem(isVideo bool, videoURL, displayURL string, resources []response.GraphDisplayResource) *models.MediaItem {
+	if isVideo {
+		if videoURL == "" {
+			return nil
+		}
+		return &models.MediaItem{
+			Type:         models.MediaTypeVideo,
+			Url:          videoURL,
+			ThumbnailUrl: displayURL,
+		}
+	}
+
+	item := &models.MediaItem{Type: models.MediaTypePhoto, Url: displayURL}
+	if len(resources) > 0 {
+		smallest := slices.MinFunc(resources, func(a, b response.GraphDisplayResource) int {
+			return cmp.Compare(a.ConfigWidth, b.ConfigWidth)
+		})
+		largest := slices.MaxFunc(resources, func(a, b response.GraphDisplayResource) int {
+			return cmp.Compare(a.ConfigWidth, b.ConfigWidth)
+		})
+		item.Url = largest.Src
+		item.ThumbnailUrl = smallest.Src
+	}
+	if item.Url == "" {
+		return nil
+	}
+	return item
 }
 
 // ExtractShortcodeFromLink will extract the media shortcode from a URL link or path
@@ -0,0 +1,166 @@
+package instagram
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/sxwebdev/downloaderbot/internal/models"
+)
+
+// TestParseGraphQL decodes recorded GraphQL responses (trimmed to the fields
+// the parser reads) of each post kind into full media.
+func TestParseGraphQL(t *testing.T) {
+	const cdn = "https://scontent.cdninstagram.com/v/t51.29350-15/"
+
+	tests := []struct {
+		fixture  string
+		caption  string
+		takenAt  int64
+		likes    uint64
+		comments uint64
+		items    []models.MediaItem
+	}{
+		{
+			fixture:  "graphql_video.json",
+			caption:  "Golden hour, one take 🌅",
+			takenAt:  1725984000,
+			likes:    8120,
+			comments: 96,
+			items: []models.MediaItem{{
+				Id:           "3466020447386474283",
+				Shortcode:    "DAZ_vid3o",
+				Type:         models.MediaTypeVideo,
+				Url:          "https://scontent.cdninstagram.com/o1/v/t16/f2/m86/reel_720.mp4?efg=eyJ2ZW5jb2RlX3RhZyI6In0",
+				Width:        1080,
+				Height:       1920,
+				Duration:     30,
+				ThumbnailUrl: cdn + "reel_cover_640.jpg?stp=c0.248.640.640a",
+				Variants: append([]*models.Variant{{
+					Url:      "https://scontent.cdninstagram.com/o1/v/t16/f2/m86/reel_720.mp4?efg=eyJ2ZW5jb2RlX3RhZyI6In0",
+					Quality:  "1080p",
+					MimeType: "video/mp4",
+					Width:    1080,
+					Height:   1920,
+					HasVideo: true,
+					HasAudio: true,
+				}}, testDashVariants...),
+			}},
+		},
+		{
+			fixture: "graphql_photo.json",
+			caption: "A quiet morning",
+			takenAt: 1725990000,
+			likes:   512,
+			items: []models.MediaItem{{
+				Id:           "3466102957551237470",
+				Shortcode:    "DAaRphoto",
+				Type:         models.MediaTypePhoto,
+				Url:          cdn + "photo_1080.jpg",
+				Width:        1080,
+				Height:       1350,
+				ThumbnailUrl: cdn + "photo_640.jpg",
+			}},
+		},
+		{
+			fixture: "graphql_sidecar.json",
+			caption: "Three from the weekend",
+			takenAt: 1726000000,
+			likes:   2048,
+			items: []models.MediaItem{
+				{
+					Id:           "3466187515417393001",
+					Shortcode:    "DAakCaA001",
+					Type:         models.MediaTypePhoto,
+					Url:          cdn + "slide1_1080.jpg",
+					Width:        1080,
+					Height:       1080,
+					ThumbnailUrl: cdn + "slide1_640.jpg",
+				},
+				{
+					Id:           "3466187515417393002",
+					Shortcode:    "DAakCaA002",
+					Type:         models.MediaTypeVideo,
+					Url:          "https://scontent.cdninstagram.com/o1/v/t16/f2/m69/slide2.mp4",
+					Width:        1080,
+					Height:       1350,
+					Duration:     11,
+					ThumbnailUrl: cdn + "slide2_cover.jpg",
+					Variants: append([]*models.Variant{{
+						Url:      "https://scontent.cdninstagram.com/o1/v/t16/f2/m69/slide2.mp4",
+						Quality:  "1080p",
+						MimeType: "video/mp4",
+						Width:    1080,
+						Height:   1350,
+						HasVideo: true,
+						HasAudio: true,
+					}}, testDashVariants...),
+				},
+				{
+					// No display resources: the display URL is all there is.
+					Id:        "3466187515417393003",
+					Shortcode: "DAakCaA003",
+					Type:      models.MediaTypePhoto,
+					Url:       cdn + "slide3_1080.jpg",
+					Width:     1080,
+					Height:    1350,
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.fixture, func(t *testing.T) {
+			body, err := os.ReadFile(filepath.Join("testdata", tc.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			media, err := parseGraphQL(body, "code")
+			if err != nil {
+				t.Fatalf("parseGraphQL: %v", err)
+			}
+			if media.Caption != tc.caption {
+				t.Fatalf("caption = %q, want %q", media.Caption, tc.caption)
+			}
+			if len(media.Items) != len(tc.items) {
+				t.Fatalf("got %d items, want %d", len(media.Items), len(tc.items))
+			}
+			for i, want := range tc.items {
+				if got := *media.Items[i]; !reflect.DeepEqual(got, want) {
+					t.Fatalf("item %d:\n got %+v\nwant %+v", i, got, want)
+				}
+			}
+			if media.Url != tc.items[0].Url || media.Type != string(tc.items[0].Type) {
+				t.Fatalf("media url/type = %s/%s, want the first item's", media.Url, media.Type)
+			}
+			if media.TakenAt != tc.takenAt || media.Likes != tc.likes || media.Comments != tc.comments {
+				t.Fatalf("taken at/likes/comments = %d/%d/%d, want %d/%d/%d",
+					media.TakenAt, media.Likes, media.Comments, tc.takenAt, tc.likes, tc.comments)
+			}
+			if media.Author != "instagram" || media.Owner == nil || !media.Owner.IsVerified || media.Owner.Id != "25025320" {
+				t.Fatalf("author = %q, owner = %+v", media.Author, media.Owner)
+			}
+		})
+	}
+}
+
+func TestParseGraphQL_Errors(t *testing.T) {
+	tests := map[string]struct {
+		body string
+		want error
+	}{
+		"rejected signature": {`for (;;);{"error":1357054}`, errSessionInvalid},
+		"empty media":        {`{"data":{"xdt_shortcode_media":null}}`, errSessionInvalid},
+		"no media url":       {`{"data":{"xdt_shortcode_media":{"shortcode":"X","is_video":true}}}`, ErrNoMedia},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseGraphQL([]byte(tc.body), "X"); !errors.Is(err, tc.want) {
+				t.Fatalf("want %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -99,6 +99,35 @@ func TestParseMediaFromHTML_Caption(t *testing.T) {
 	}
 }
 
+func TestParseMediaFromHTML_PostDetails(t *testing.T) {
+	const code = "DbAxzDXtdIo"
+
+	// The decoy and the caption carry users and counts of their own, which
+	// must not be taken for the post's.
+	const decoy = `{"code":"OTHER1","taken_at":1,"like_count":1,` +
+		`"user":{"username":"stranger","is_verified":false},` +
+		`"original_height":1920,"original_width":1080}`
+	main := `{"code":"` + code + `",` +
+		`"caption":{"pk":"2","text":"a \"quoted\" {caption}","user":{"username":"commenter"},"like_count":3},` +
+		`"taken_at":1726000000,"like_count":4512,"comment_count":87,` +
+		`"user":{"pk":"25025320","username":"instagram","is_verified":true,"is_private":false},` +
+		`"original_height":1920,"original_width":1080,` +
+		`"video_versions":[{"type":101,"url":"https:\/\/cdn.example\/reel.mp4"}]}`
+	html := `<html><head><style>body{margin:0}</style></head><body>` +
+		`<script type="application/json">{"items":[` + decoy + `,` + main + `]}</script></body></html>`
+
+	media, err := parseMediaFromHTML(html, code)
+	if err != nil {
+		t.Fatalf("parseMediaFromHTML: %v", err)
+	}
+	if media.Author != "instagram" || media.Owner == nil || !media.Owner.IsVerified {
+		t.Fatalf("author = %q, owner = %+v", media.Author, media.Owner)
+	}
+	if media.TakenAt != 1726000000 || media.Likes != 4512 || media.Comments != 87 {
+		t.Fatalf("taken at/likes/comments = %d/%d/%d", media.TakenAt, media.Likes, media.Comments)
+	}
+}
+
 func firstItem(t *testing.T, html string) *models.MediaItem {
 	t.Helper()
 	media, err := parseMediaFromHTML(html, "ABC123")
@@ -117,3 +146,61 @@ func assertDims(t *testing.T, item *models.MediaItem, wantW, wantH int) {
 		t.Fatalf("dimensions = %dx%d, want %dx%d", item.Width, item.Height, wantW, wantH)
 	}
 }
+
+func TestParseMediaFromHTML_ReelAudio(t *testing.T) {
+	const code = "DbAxzDXtdIo"
+	const reel = `"original_height":1920,"original_width":1080,` +
+		`"video_versions":[{"type":101,"url":"https:\/\/cdn.example\/reel.mp4"}]`
+
+	tests := []struct {
+		name string
+		post string
+		want *models.MediaItem
+	}{
+		{
+			name: "licensed song",
+			post: `"clips_metadata":{"music_info":{"music_asset_info":{"title":"Golden Hour","display_artist":"JVKE",` +
+				`"progressive_download_url":"https://cdn.example/song.m4a","duration_in_ms":30500}},"original_sound_info":null},`,
+			want: &models.MediaItem{Url: "https://cdn.example/song.m4a", Title: "Golden Hour", Artist: "JVKE", Duration: 31},
+		},
+		{
+			name: "original audio named by the attribution",
+			post: `"clips_metadata":{"music_info":null,"original_sound_info":{"original_audio_title":"",` +
+				`"progressive_download_url":"https://cdn.example/original.m4a","duration_in_ms":12000,"ig_artist":{"username":"someone"}}},` +
+				`"clips_music_attribution_info":{"artist_name":"someone","song_name":"Original audio"},`,
+			want: &models.MediaItem{Url: "https://cdn.example/original.m4a", Title: "Original audio", Artist: "someone", Duration: 12},
+		},
+		{
+			name: "attribution without an audio url",
+			post: `"clips_music_attribution_info":{"artist_name":"JVKE","song_name":"Golden Hour"},`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			html := `{"items":[{"code":"` + code + `",` + tc.post + reel + `}]}`
+			media, err := parseMediaFromHTML(html, code)
+			if err != nil {
+				t.Fatalf("parseMediaFromHTML: %v", err)
+			}
+			if tc.want == nil {
+				if len(media.Items) != 1 {
+					t.Fatalf("got %d items, want the reel alone", len(media.Items))
+				}
+				return
+			}
+
+			if len(media.Items) != 2 || media.Items[0].Type != models.MediaTypeVideo {
+				t.Fatalf("got %d items, want the reel then its audio", len(media.Items))
+			}
+			audio := media.Items[1]
+			if audio.Type != models.MediaTypeAudio || audio.Shortcode != code {
+				t.Fatalf("audio item = %+v", audio)
+			}
+			if audio.Url != tc.want.Url || audio.Title != tc.want.Title || audio.Artist != tc.want.Artist || audio.Duration != tc.want.Duration {
+				t.Fatalf("audio = %q %q %q %ds, want %q %q %q %ds", audio.Url, audio.Title, audio.Artist, audio.Duration,
+					tc.want.Url, tc.want.Title, tc.want.Artist, tc.want.Duration)
+			}
+		})
+	}
+}
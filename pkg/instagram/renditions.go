@@ -0,0 +1,181 @@
+package instagram
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sxwebdev/downloaderbot/internal/models"
+)
+
+// reDashManifest finds the DASH manifest of a video, an MPD document embedded
+// as a JSON string.
+var reDashManifest = regexp.MustCompile(`"video_dash_manifest":"`)
+
+// videoVersion is an entry of "video_versions": a progressive MP4 with both
+// video and audio.
+type videoVersion struct {
+	Type   int    `json:"type"`
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// renditionsForItem returns the variants of the video whose "video_versions"
+// key starts at versionsPos in block: every progressive version, then the
+// representations of the nearest DASH manifest. It returns nil when the
+// video comes in a single format.
+func renditionsForItem(block string, versionsPos int) []*models.Variant {
+	variants := progressiveVariants(block, versionsPos)
+	if m := nearestSubmatch(block, reDashManifest, versionsPos); m != nil {
+		if manifest, ok := jsonStringAt(block, m[1]-1); ok {
+			dash, _ := parseDashManifest(manifest)
+			variants = append(variants, dash...)
+		}
+	}
+	if len(variants) < 2 {
+		return nil
+	}
+	return variants
+}
+
+// progressiveVariants decodes the "video_versions" array whose key starts at
+// pos. Instagram lists a URL once per version type, so repeats are dropped.
+func progressiveVariants(block string, pos int) []*models.Variant {
+	start := strings.IndexByte(block[pos:], '[')
+	if start < 0 {
+		return nil
+	}
+	arr, ok := balancedJSON(block, pos+start)
+	if !ok {
+		return nil
+	}
+	var versions []videoVersion
+	if err := json.Unmarshal([]byte(arr), &versions); err != nil {
+		return nil
+	}
+
+	variants := make([]*models.Variant, 0, len(versions))
+	seen := make(map[string]bool, len(versions))
+	for _, v := range versions {
+		if v.URL == "" || seen[v.URL] {
+			continue
+		}
+		seen[v.URL] = true
+		variants = append(variants, &models.Variant{
+			Url:      v.URL,
+			Quality:  qualityLabel(v.Width, v.Height),
+			MimeType: "video/mp4",
+			Width:    v.Width,
+			Height:   v.Height,
+			HasVideo: true,
+			HasAudio: true,
+		})
+	}
+	return variants
+}
+
+// mpd is the part of a DASH manifest the representations are read from.
+type mpd struct {
+	Periods []struct {
+		AdaptationSets []struct {
+			ContentType     string `xml:"contentType,attr"`
+			MimeType        string `xml:"mimeType,attr"`
+			Representations []struct {
+				MimeType     string `xml:"mimeType,attr"`
+				Codecs       string `xml:"codecs,attr"`
+				Bandwidth    int    `xml:"bandwidth,attr"`
+				Width        int    `xml:"width,attr"`
+				Height       int    `xml:"height,attr"`
+				QualityLabel string `xml:"FBQualityLabel,attr"`
+				BaseURL      string `xml:"BaseURL"`
+			} `xml:"Representation"`
+		} `xml:"AdaptationSet"`
+	} `xml:"Period"`
+}
+
+// parseDashManifest returns a variant per representation of a DASH manifest.
+// Instagram streams video and audio separately, so each variant has one or
+// the other.
+func parseDashManifest(manifest string) ([]*models.Variant, error) {
+	var doc mpd
+	if err := xml.Unmarshal([]byte(manifest), &doc); err != nil {
+		return nil, fmt.Errorf("invalid dash manifest: %w", err)
+	}
+
+	var variants []*models.Variant
+	for _, period := range doc.Periods {
+		for _, set := range period.AdaptationSets {
+			for _, rep := range set.Representations {
+				url := strings.TrimSpace(rep.BaseURL)
+				if url == "" {
+					continue
+				}
+				mimeType := rep.MimeType
+				if mimeType == "" {
+					mimeType = set.MimeType
+				}
+				contentType := set.ContentType
+				if contentType == "" {
+					contentType, _, _ = strings.Cut(mimeType, "/")
+				}
+
+				v := &models.Variant{
+					Url:      url,
+					MimeType: mimeType,
+					Codec:    rep.Codecs,
+					Bitrate:  rep.Bandwidth,
+					Width:    rep.Width,
+					Height:   rep.Height,
+					HasVideo: contentType == "video",
+					HasAudio: contentType == "audio",
+				}
+				switch {
+				case !v.HasVideo:
+					v.Quality = fmt.Sprintf("%dk", rep.Bandwidth/1000)
+				case rep.QualityLabel != "":
+					v.Quality = rep.QualityLabel
+				default:
+					v.Quality = qualityLabel(rep.Width, rep.Height)
+				}
+				variants = append(variants, v)
+			}
+		}
+	}
+	return variants, nil
+}
+
+// qualityLabel names a video size after its shorter side, like "720p" for a
+// 720x1280 reel; "" when the size is unknown.
+func qualityLabel(width, height int) string {
+	side := min(width, height)
+	if side <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%dp", side)
+}
+
+// jsonStringAt decodes the JSON string literal whose opening quote is at
+// s[i].
+func jsonStringAt(s string, i int) (string, bool) {
+	if i < 0 || i >= len(s) || s[i] != '"' {
+		return "", false
+	}
+	for j, esc := i+1, false; j < len(s); j++ {
+		switch {
+		case esc:
+			esc = false
+		case s[j] == '\\':
+			esc = true
+		case s[j] == '"':
+			var out string
+			if err := json.Unmarshal([]byte(s[i:j+1]), &out); err != nil {
+				return "", false
+			}
+			return out, true
+		}
+	}
+	return "", false
+}
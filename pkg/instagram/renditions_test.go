@@ -0,0 +1,94 @@
+package instagram
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/sxwebdev/downloaderbot/internal/models"
+)
+
+// testManifest is a reel's DASH manifest, trimmed to a video and an audio
+// representation.
+const testManifest = `<?xml version="1.0" encoding="UTF-8"?>
+<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" mediaPresentationDuration="PT29.566S" type="static">
+<Period id="0" duration="PT29.566S">
+<AdaptationSet id="0" contentType="video" segmentAlignment="true" subsegmentStartsWithSAP="1">
+<Representation id="1" bandwidth="1650000" codecs="avc1.4d401f" mimeType="video/mp4" width="720" height="1280" FBQualityLabel="720p" FBQualityClass="hd">
+<BaseURL>https://cdn.example/v720.mp4?efg=a&amp;oh=b</BaseURL>
+</Representation>
+<Representation id="2" bandwidth="480000" codecs="avc1.4d401e" mimeType="video/mp4" width="360" height="640">
+<BaseURL>https://cdn.example/v360.mp4</BaseURL>
+</Representation>
+</AdaptationSet>
+<AdaptationSet id="1" contentType="audio" mimeType="audio/mp4">
+<Representation id="3" bandwidth="64000" codecs="mp4a.40.5">
+<BaseURL>https://cdn.example/audio.mp4</BaseURL>
+</Representation>
+</AdaptationSet>
+</Period>
+</MPD>`
+
+var testDashVariants = []*models.Variant{
+	{Url: "https://cdn.example/v720.mp4?efg=a&oh=b", Quality: "720p", MimeType: "video/mp4", Codec: "avc1.4d401f", Bitrate: 1650000, Width: 720, Height: 1280, HasVideo: true},
+	{Url: "https://cdn.example/v360.mp4", Quality: "360p", MimeType: "video/mp4", Codec: "avc1.4d401e", Bitrate: 480000, Width: 360, Height: 640, HasVideo: true},
+	{Url: "https://cdn.example/audio.mp4", Quality: "64k", MimeType: "audio/mp4", Codec: "mp4a.40.5", Bitrate: 64000, HasAudio: true},
+}
+
+func TestParseDashManifest(t *testing.T) {
+	got, err := parseDashManifest(testManifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, testDashVariants) {
+		t.Fatalf("variants:\n got %s\nwant %s", dump(got), dump(testDashVariants))
+	}
+
+	if _, err := parseDashManifest("not xml"); err == nil {
+		t.Fatal("want an error for a broken manifest")
+	}
+}
+
+func TestParseMediaFromHTML_Renditions(t *testing.T) {
+	const code = "DbAxzDXtdIo"
+	manifest, _ := json.Marshal(testManifest)
+	html := `{"items":[{"code":"` + code + `","original_height":1920,"original_width":1080,` +
+		`"video_dash_manifest":` + string(manifest) + `,` +
+		`"video_versions":[` +
+		`{"type":101,"url":"https:\/\/cdn.example\/p1080.mp4","width":1080,"height":1920},` +
+		`{"type":102,"url":"https:\/\/cdn.example\/p480.mp4","width":480,"height":854},` +
+		`{"type":103,"url":"https:\/\/cdn.example\/p480.mp4","width":480,"height":854}]}]}`
+
+	media, err := parseMediaFromHTML(html, code)
+	if err != nil {
+		t.Fatalf("parseMediaFromHTML: %v", err)
+	}
+	item := media.Items[0]
+	if item.Url != "https://cdn.example/p1080.mp4" || item.Duration != 30 {
+		t.Fatalf("default format = %s, %ds", item.Url, item.Duration)
+	}
+
+	want := append([]*models.Variant{
+		{Url: "https://cdn.example/p1080.mp4", Quality: "1080p", MimeType: "video/mp4", Width: 1080, Height: 1920, HasVideo: true, HasAudio: true},
+		{Url: "https://cdn.example/p480.mp4", Quality: "480p", MimeType: "video/mp4", Width: 480, Height: 854, HasVideo: true, HasAudio: true},
+	}, testDashVariants...)
+	if !reflect.DeepEqual(item.Variants, want) {
+		t.Fatalf("variants:\n got %s\nwant %s", dump(item.Variants), dump(want))
+	}
+}
+
+func TestParseMediaFromHTML_SingleRendition(t *testing.T) {
+	html := `{"items":[{"code":"X","video_versions":[{"type":101,"url":"https:\/\/cdn.example\/v.mp4","width":720,"height":1280}]}]}`
+	media, err := parseMediaFromHTML(html, "X")
+	if err != nil {
+		t.Fatalf("parseMediaFromHTML: %v", err)
+	}
+	if media.Items[0].Variants != nil {
+		t.Fatalf("want no variants for a single format, got %s", dump(media.Items[0].Variants))
+	}
+}
+
+func dump(variants []*models.Variant) string {
+	b, _ := json.MarshalIndent(variants, "", "  ")
+	return string(b)
+}
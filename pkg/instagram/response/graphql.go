@@ -3,46 +3,35 @@ package response
 type GrpahSQLResponse struct {
 	Data struct {
 		XdtShortcodeMedia struct {
-			Typename                 string `json:"__typename"`
-			IsXDTGraphMediaInterface string `json:"__isXDTGraphMediaInterface"`
-			ID                       string `json:"id"`
-			Shortcode                string `json:"shortcode"`
-			ThumbnailSrc             string `json:"thumbnail_src"`
-			Dimensions               struct {
-				Height int `json:"height"`
-				Width  int `json:"width"`
-			} `json:"dimensions"`
-			GatingInfo              any `json:"gating_info"`
-			FactCheckOverallRating  any `json:"fact_check_overall_rating"`
-			FactCheckInformation    any `json:"fact_check_information"`
-			SensitivityFrictionInfo any `json:"sensitivity_friction_info"`
-			SharingFrictionInfo     struct {
+			Typename                 string          `json:"__typename"`
+			IsXDTGraphMediaInterface string          `json:"__isXDTGraphMediaInterface"`
+			ID                       string          `json:"id"`
+			Shortcode                string          `json:"shortcode"`
+			ThumbnailSrc             string          `json:"thumbnail_src"`
+			Dimensions               GraphDimensions `json:"dimensions"`
+			GatingInfo               any             `json:"gating_info"`
+			FactCheckOverallRating   any             `json:"fact_check_overall_rating"`
+			FactCheckInformation     any             `json:"fact_check_information"`
+			SensitivityFrictionInfo  any             `json:"sensitivity_friction_info"`
+			SharingFrictionInfo      struct {
 				ShouldHaveSharingFriction bool `json:"should_have_sharing_friction"`
 				BloksAppURL               any  `json:"bloks_app_url"`
 			} `json:"sharing_friction_info"`
-			MediaOverlayInfo any    `json:"media_overlay_info"`
-			MediaPreview     string `json:"media_preview"`
-			DisplayURL       string `json:"display_url"`
-			DisplayResources []struct {
-				Src          string `json:"src"`
-				ConfigWidth  int    `json:"config_width"`
-				ConfigHeight int    `json:"config_height"`
-			} `json:"display_resources"`
-			AccessibilityCaption any `json:"accessibility_caption"`
-			DashInfo             struct {
-				IsDashEligible    bool `json:"is_dash_eligible"`
-				VideoDashManifest any  `json:"video_dash_manifest"`
-				NumberOfQualities int  `json:"number_of_qualities"`
-			} `json:"dash_info"`
-			HasAudio                  bool    `json:"has_audio"`
-			VideoURL                  string  `json:"video_url"`
-			VideoViewCount            int     `json:"video_view_count"`
-			VideoPlayCount            int     `json:"video_play_count"`
-			EncodingStatus            any     `json:"encoding_status"`
-			IsPublished               bool    `json:"is_published"`
-			ProductType               string  `json:"product_type"`
-			Title                     string  `json:"title"`
-			VideoDuration             float64 `json:"video_duration"`
+			MediaOverlayInfo          any                    `json:"media_overlay_info"`
+			MediaPreview              string                 `json:"media_preview"`
+			DisplayURL                string                 `json:"display_url"`
+			DisplayResources          []GraphDisplayResource `json:"display_resources"`
+			AccessibilityCaption      any                    `json:"accessibility_caption"`
+			DashInfo                  GraphDashInfo          `json:"dash_info"`
+			HasAudio                  bool                   `json:"has_audio"`
+			VideoURL                  string                 `json:"video_url"`
+			VideoViewCount            int                    `json:"video_view_count"`
+			VideoPlayCount            int                    `json:"video_play_count"`
+			EncodingStatus            any                    `json:"encoding_status"`
+			IsPublished               bool                   `json:"is_published"`
+			ProductType               string                 `json:"product_type"`
+			Title                     string                 `json:"title"`
+			VideoDuration             float64                `json:"video_duration"`
 			ClipsMusicAttributionInfo struct {
 				ArtistName            string `json:"artist_name"`
 				SongName              string `json:"song_name"`
@@ -51,7 +40,12 @@ type GrpahSQLResponse struct {
 				ShouldMuteAudioReason string `json:"should_mute_audio_reason"`
 				AudioID               string `json:"audio_id"`
 			} `json:"clips_music_attribution_info"`
-			IsVideo               bool   `json:"is_video"`
+			IsVideo               bool `json:"is_video"`
+			EdgeSidecarToChildren struct {
+				Edges []struct {
+					Node GraphSidecarChild `json:"node"`
+				} `json:"edges"`
+			} `json:"edge_sidecar_to_children"`
 			TrackingToken         string `json:"tracking_token"`
 			UpcomingEvent         any    `json:"upcoming_event"`
 			EdgeMediaToTaggedUser struct {
@@ -204,3 +198,38 @@ type GrpahSQLResponse struct {
 		IsFinal bool `json:"is_final"`
 	} `json:"extensions"`
 }
+
+// GraphDimensions is the size of a media, in pixels.
+type GraphDimensions struct {
+	Height int `json:"height"`
+	Width  int `json:"width"`
+}
+
+// GraphDisplayResource is one rendition of an image, the largest last.
+type GraphDisplayResource struct {
+	Src          string `json:"src"`
+	ConfigWidth  int    `json:"config_width"`
+	ConfigHeight int    `json:"config_height"`
+}
+
+// GraphDashInfo holds the DASH manifest of a video, an MPD document.
+type GraphDashInfo struct {
+	IsDashEligible    bool   `json:"is_dash_eligible"`
+	VideoDashManifest string `json:"video_dash_manifest"`
+	NumberOfQualities int    `json:"number_of_qualities"`
+}
+
+// GraphSidecarChild is one photo or video of a carousel (sidecar) post.
+type GraphSidecarChild struct {
+	Typename         string                 `json:"__typename"`
+	ID               string                 `json:"id"`
+	Shortcode        string                 `json:"shortcode"`
+	Dimensions       GraphDimensions        `json:"dimensions"`
+	DisplayURL       string                 `json:"display_url"`
+	DisplayResources []GraphDisplayResource `json:"display_resources"`
+	IsVideo          bool                   `json:"is_video"`
+	HasAudio         bool                   `json:"has_audio"`
+	VideoURL         string                 `json:"video_url"`
+	VideoDuration    float64                `json:"video_duration"`
+	DashInfo         GraphDashInfo          `json:"dash_info"`
+}
@@ -4,14 +4,33 @@ package tiktok
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"net/url"
 	"regexp"
+	"strings"
 
 	"github.com/sxwebdev/downloaderbot/internal/models"
 	"github.com/sxwebdev/downloaderbot/internal/util"
 	"github.com/sxwebdev/downloaderbot/pkg/browser"
 )
 
+// ErrNoVideo is returned when the loaded page carries no playable URL, which is
+// what TikTok serves to clients it flags as bots (captcha / login walls).
+var ErrNoVideo = errors.New("no video url found on tiktok page")
+
+// Errors for pages that tell why the video is missing.
+var (
+	ErrNotFound = errors.New("tiktok video not found")
+	ErrPrivate  = errors.New("tiktok video is private")
+)
+
+// The rehydration JSON of a page without a video carries a status code:
+// 10204 for a deleted or never existing video, 10222 for a private one.
+var (
+	reStatusNotFound = regexp.MustCompile(`"statusCode":10204\b`)
+	reStatusPrivate  = regexp.MustCompile(`"statusCode":10222\b`)
+)
+
 // The playable URL is embedded in the page's __UNIVERSAL_DATA_FOR_REHYDRATION__
 // JSON. playAddr is the primary playback URL; downloadAddr is a fallback.
 var (
@@ -20,6 +39,47 @@ var (
 	reDesc         = regexp.MustCompile(`"desc":"((?:[^"\\]|\\.)*)"`)
 )
 
+// Video page and short link paths: /@user/video/<id>, /v/<id>.html, /embed/v2/<id>,
+// tiktok.com/t/<code> and vt./vm.tiktok.com/<code>.
+var (
+	reVideoPath = regexp.MustCompile(`^/(?:@[^/]+/video|v|embed(?:/v2)?)/(\d+)`)
+	reShortPath = regexp.MustCompile(`^/(?:t/)?[A-Za-z0-9]+/?$`)
+)
+
+// IsVideoLink reports whether u points at a single TikTok video, either its page
+// or a short link redirecting to it. Profiles, tags and search pages don't.
+func IsVideoLink(u *url.URL) bool {
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case host == "vt.tiktok.com" || host == "vm.tiktok.com":
+		return reShortPath.MatchString(u.Path)
+	case host == "tiktok.com" || strings.HasSuffix(host, ".tiktok.com"):
+		return reVideoPath.MatchString(u.Path) ||
+			(strings.HasPrefix(u.Path, "/t/") && reShortPath.MatchString(u.Path))
+	}
+	return false
+}
+
+// CanonicalURL returns the form all links of the video u points at share:
+// https://www.tiktok.com/@/video/<id> for video pages (TikTok opens a video
+// under any username) and, for short links, whose video is only known after
+// following them, the short link without query. ok is false when u is not a
+// video link.
+func CanonicalURL(u *url.URL) (canonical string, ok bool) {
+	if !IsVideoLink(u) {
+		return "", false
+	}
+	if m := reVideoPath.FindStringSubmatch(u.Path); m != nil {
+		return "https://www.tiktok.com/@/video/" + m[1], true
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host != "vt.tiktok.com" && host != "vm.tiktok.com" {
+		host = "www.tiktok.com"
+	}
+	return "https://" + host + "/" + strings.Trim(u.Path, "/") + "/", true
+}
+
 // GetVideo loads a TikTok video page (short vt.tiktok.com / vm.tiktok.com links
 // are followed automatically) and returns the playable media.
 //
@@ -39,7 +99,13 @@ func GetVideo(ctx context.Context, link string) (*models.Media, error) {
 		url = util.JSONUnescape(m[1])
 	}
 	if url == "" {
-		return nil, fmt.Errorf("no video url found on tiktok page")
+		switch {
+		case reStatusNotFound.MatchString(res.HTML):
+			return nil, ErrNotFound
+		case reStatusPrivate.MatchString(res.HTML):
+			return nil, ErrPrivate
+		}
+		return nil, ErrNoVideo
 	}
 
 	headers := map[string]string{
@@ -2,6 +2,7 @@ package tiktok_test
 
 import (
 	"net/http"
+	"net/url"
 	"os"
 	"testing"
 	"time"
@@ -10,6 +11,55 @@ import (
 	"github.com/sxwebdev/downloaderbot/pkg/tiktok"
 )
 
+func TestIsVideoLink(t *testing.T) {
+	tests := map[string]bool{
+		"https://www.tiktok.com/@user.name/video/7301234567890123456": true,
+		"https://m.tiktok.com/v/7301234567890123456.html":             true,
+		"https://www.tiktok.com/embed/v2/7301234567890123456":         true,
+		"https://www.tiktok.com/t/ZTRabc123/":                         true,
+		"https://vt.tiktok.com/ZSCNjNQFC/":                            true,
+		"https://vm.tiktok.com/ZMabc123":                              true,
+		"https://us.tiktok.com/@user/video/7301234567890123456":       true,
+		"https://www.tiktok.com/@user.name":                           false,
+		"https://www.tiktok.com/tag/cats":                             false,
+		"https://www.tiktok.com/explore":                              false,
+		"https://vt.tiktok.com/":                                      false,
+		"https://example.com/@user/video/7301234567890123456":         false,
+	}
+
+	for link, want := range tests {
+		u, err := url.Parse(link)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := tiktok.IsVideoLink(u); got != want {
+			t.Errorf("IsVideoLink(%s) = %v, want %v", link, got, want)
+		}
+	}
+}
+
+func TestCanonicalURL(t *testing.T) {
+	tests := map[string]string{
+		"https://www.tiktok.com/@user.name/video/7301234567890123456?is_from_webapp=1&_r=1": "https://www.tiktok.com/@/video/7301234567890123456",
+		"https://m.tiktok.com/v/7301234567890123456.html":                                   "https://www.tiktok.com/@/video/7301234567890123456",
+		"https://www.tiktok.com/embed/v2/7301234567890123456":                               "https://www.tiktok.com/@/video/7301234567890123456",
+		"https://us.tiktok.com/t/ZTRabc123":                                                 "https://www.tiktok.com/t/ZTRabc123/",
+		"https://VT.tiktok.com/ZSCNjNQFC/?k=1":                                              "https://vt.tiktok.com/ZSCNjNQFC/",
+		"https://www.tiktok.com/@user.name":                                                 "",
+	}
+
+	for link, want := range tests {
+		u, err := url.Parse(link)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, ok := tiktok.CanonicalURL(u)
+		if got != want || ok != (want != "") {
+			t.Errorf("CanonicalURL(%s) = %q, %v; want %q", link, got, ok, want)
+		}
+	}
+}
+
 func TestGetVideo(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test in short mode")
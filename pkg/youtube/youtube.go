@@ -3,8 +3,8 @@ package youtube
 import (
 	"context"
 	"fmt"
+	"mime"
 	"slices"
-	"strconv"
 	"strings"
 
 	"github.com/kkdai/youtube/v2"
@@ -40,36 +40,64 @@ func GetVideoByID(ctx context.Context, id string) (*models.Media, error) {
 		return nil, fmt.Errorf("empty formats list")
 	}
 
+	item := &models.MediaItem{
+		Id:       video.ID,
+		Type:     models.MediaTypeVideo,
+		Duration: int(video.Duration.Seconds()),
+		Variants: make([]*models.Variant, len(formats)),
+	}
+
 	resp := &models.Media{
 		Title:   video.Title,
 		Caption: video.Description,
-		Items:   make([]*models.MediaItem, len(formats)),
+		Type:    string(models.MediaTypeVideo),
+		Items:   []*models.MediaItem{item},
 	}
 
 	if len(video.Thumbnails) > 0 {
 		resp.Url = video.Thumbnails[len(video.Thumbnails)-1].URL
+		item.ThumbnailUrl = resp.Url
 	}
 
 	for index, format := range formats {
-		itemType := models.MediaTypeVideo
-		if strings.Contains(format.MimeType, "audio") {
-			itemType = models.MediaTypeAudio
-		}
+		item.Variants[index] = variantOf(format)
+	}
 
-		resp.Items[index] = &models.MediaItem{
-			Id:                strconv.Itoa(index),
-			Type:              itemType,
-			Url:               format.URL,
-			Quality:           format.QualityLabel,
-			ContentLength:     format.ContentLength,
-			MimeType:          format.MimeType,
-			VideoWithoutAudio: format.AudioChannels == 0,
+	// The item describes the best format with both video and audio, which
+	// can be played as is; the first format when there is none.
+	def := item.Variants[0]
+	for _, v := range item.Variants {
+		if v.HasVideo && v.HasAudio && (!def.HasVideo || !def.HasAudio || v.Height > def.Height) {
+			def = v
 		}
 	}
+	*item = *item.WithVariant(def)
 
 	return resp, nil
 }
 
+// variantOf converts a YouTube format, splitting its MIME type from the codecs
+// parameter.
+func variantOf(format youtube.Format) *models.Variant {
+	mimeType, codec := format.MimeType, ""
+	if mediaType, params, err := mime.ParseMediaType(format.MimeType); err == nil {
+		mimeType, codec = mediaType, params["codecs"]
+	}
+
+	return &models.Variant{
+		Url:           format.URL,
+		Quality:       format.QualityLabel,
+		MimeType:      mimeType,
+		Codec:         codec,
+		Bitrate:       format.Bitrate,
+		Width:         format.Width,
+		Height:        format.Height,
+		ContentLength: format.ContentLength,
+		HasVideo:      !strings.HasPrefix(mimeType, "audio/"),
+		HasAudio:      format.AudioChannels > 0,
+	}
+}
+
 func ExtractShortcodeFromLink(link string) (string, error) {
 	return youtube.ExtractVideoID(link)
 }
@@ -0,0 +1,56 @@
+module github.com/iawia002/lux
+
+go 1.22
+
+require (
+	github.com/EDDYCJY/fake-useragent v0.2.0
+	github.com/MercuryEngineering/CookieMonster v0.0.0-20180304172713-1584578b3403
+	github.com/PuerkitoBio/goquery v1.8.0
+	github.com/buger/jsonparser v1.1.1
+	github.com/cheggaaa/pb/v3 v3.0.8
+	github.com/dop251/goja v0.0.0-20230304130813-e2f543bf4b4c
+	github.com/fatih/color v1.13.0
+	github.com/gocolly/colly/v2 v2.1.0
+	github.com/itchyny/gojq v0.12.7
+	github.com/json-iterator/go v1.1.12
+	github.com/kkdai/youtube/v2 v2.7.18
+	github.com/kr/pretty v0.3.0
+	github.com/pkg/errors v0.9.1
+	github.com/robertkrimen/otto v0.0.0-20211024170158-b87d35c0b86f
+	github.com/urfave/cli/v2 v2.6.0
+)
+
+require (
+	github.com/VividCortex/ewma v1.2.0 // indirect
+	github.com/andybalholm/cascadia v1.3.1 // indirect
+	github.com/antchfx/htmlquery v1.2.3 // indirect
+	github.com/antchfx/xmlquery v1.3.1 // indirect
+	github.com/antchfx/xpath v1.1.10 // indirect
+	github.com/bitly/go-simplejson v0.5.0 // indirect
+	github.com/cpuguy83/go-md2man/v2 v2.0.1 // indirect
+	github.com/dlclark/regexp2 v1.7.0 // indirect
+	github.com/go-sourcemap/sourcemap v2.1.3+incompatible // indirect
+	github.com/gobwas/glob v0.2.3 // indirect
+	github.com/golang/groupcache v0.0.0-20200121045136-8c9f03a8e57e // indirect
+	github.com/golang/protobuf v1.5.0 // indirect
+	github.com/google/pprof v0.0.0-20230207041349-798e818bf904 // indirect
+	github.com/itchyny/timefmt-go v0.1.3 // indirect
+	github.com/kennygrant/sanitize v1.2.4 // indirect
+	github.com/kr/text v0.2.0 // indirect
+	github.com/mattn/go-colorable v0.1.12 // indirect
+	github.com/mattn/go-isatty v0.0.14 // indirect
+	github.com/mattn/go-runewidth v0.0.14 // indirect
+	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
+	github.com/modern-go/reflect2 v1.0.2 // indirect
+	github.com/rivo/uniseg v0.4.3 // indirect
+	github.com/rogpeppe/go-internal v1.9.0 // indirect
+	github.com/russross/blackfriday/v2 v2.1.0 // indirect
+	github.com/saintfish/chardet v0.0.0-20120816061221-3af4cd4741ca // indirect
+	github.com/temoto/robotstxt v1.1.1 // indirect
+	golang.org/x/net v0.23.0 // indirect
+	golang.org/x/sys v0.18.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+	google.golang.org/appengine v1.6.6 // indirect
+	google.golang.org/protobuf v1.33.0 // indirect
+	gopkg.in/sourcemap.v1 v1.0.5 // indirect
+)